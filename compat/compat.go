@@ -0,0 +1,297 @@
+package compat
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gekatateam/protomap"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Change is a breaking change of schema element, like message, field or enum value, identified by its full name
+// in the previous schema. Wire changes break binary encoding, JSON changes break JSON and decoded maps,
+// which use field and enum value names.
+type Change struct {
+	Path   string
+	Reason string
+	Wire   bool
+	JSON   bool
+}
+
+func (c Change) String() string {
+	var breaks []string
+	if c.Wire {
+		breaks = append(breaks, "wire")
+	}
+	if c.JSON {
+		breaks = append(breaks, "json")
+	}
+	return fmt.Sprintf("%v: %v (%v)", c.Path, c.Reason, strings.Join(breaks, ", "))
+}
+
+// CheckMessage compares message and all messages and enums it references in prev schema
+// with the same elements of next schema and returns breaking changes.
+func CheckMessage(prev, next *protomap.Mapper, messageName string) ([]Change, error) {
+	desc, err := prev.FindMessage(messageName)
+	if err != nil {
+		return nil, err
+	}
+
+	c := newChecker(next)
+	c.visitMessage(desc)
+	return c.changes, nil
+}
+
+// CheckFiles compares all messages and enums of prev schema files with the same elements of next schema
+// and returns breaking changes.
+func CheckFiles(prev, next *protomap.Mapper) []Change {
+	c := newChecker(next)
+	for _, file := range prev.Files() {
+		c.visitTypes(file.Messages(), file.Enums())
+	}
+	return c.changes
+}
+
+type checker struct {
+	next    *protomap.Mapper
+	visited map[protoreflect.FullName]bool
+	changes []Change
+}
+
+func newChecker(next *protomap.Mapper) *checker {
+	return &checker{
+		next:    next,
+		visited: make(map[protoreflect.FullName]bool),
+	}
+}
+
+func (c *checker) add(path protoreflect.FullName, wire, json bool, format string, args ...any) {
+	c.changes = append(c.changes, Change{
+		Path:   string(path),
+		Reason: fmt.Sprintf(format, args...),
+		Wire:   wire,
+		JSON:   json,
+	})
+}
+
+func (c *checker) find(name protoreflect.FullName) protoreflect.Descriptor {
+	desc, err := c.next.Resolver().FindDescriptorByName(name)
+	if err != nil {
+		return nil
+	}
+	return desc
+}
+
+func (c *checker) visitTypes(messages protoreflect.MessageDescriptors, enums protoreflect.EnumDescriptors) {
+	for i := 0; i < messages.Len(); i++ {
+		if message := messages.Get(i); !message.IsMapEntry() {
+			c.checkMessage(message)
+			c.visitTypes(message.Messages(), message.Enums())
+		}
+	}
+
+	for i := 0; i < enums.Len(); i++ {
+		c.checkEnum(enums.Get(i))
+	}
+}
+
+// visitMessage checks message and messages and enums referenced by its fields.
+func (c *checker) visitMessage(message protoreflect.MessageDescriptor) {
+	if c.visited[message.FullName()] {
+		return
+	}
+	c.checkMessage(message)
+
+	fields := message.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if field.IsMap() {
+			field = field.MapValue()
+		}
+
+		if field.Message() != nil {
+			c.visitMessage(field.Message())
+		}
+
+		if field.Enum() != nil && !c.visited[field.Enum().FullName()] {
+			c.checkEnum(field.Enum())
+		}
+	}
+}
+
+func (c *checker) checkMessage(prev protoreflect.MessageDescriptor) {
+	c.visited[prev.FullName()] = true
+
+	next, ok := c.find(prev.FullName()).(protoreflect.MessageDescriptor)
+	if !ok {
+		c.add(prev.FullName(), true, true, "message removed")
+		return
+	}
+
+	fields := prev.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		c.checkField(fields.Get(i), next)
+	}
+
+	// numbers and names reserved in previous schema must not be reused
+	nextFields := next.Fields()
+	for i := 0; i < nextFields.Len(); i++ {
+		field := nextFields.Get(i)
+		if prev.ReservedRanges().Has(field.Number()) {
+			c.add(field.FullName(), true, false, "field uses number %v, reserved in previous schema", field.Number())
+		}
+		if prev.ReservedNames().Has(field.Name()) {
+			c.add(field.FullName(), false, true, "field uses name %v, reserved in previous schema", field.Name())
+		}
+	}
+}
+
+func (c *checker) checkField(prev protoreflect.FieldDescriptor, next protoreflect.MessageDescriptor) {
+	field := next.Fields().ByNumber(prev.Number())
+	if field == nil {
+		switch moved := next.Fields().ByName(prev.Name()); {
+		case moved != nil:
+			c.add(prev.FullName(), true, false, "field number changed from %v to %v", prev.Number(), moved.Number())
+		case next.ReservedRanges().Has(prev.Number()):
+			c.add(prev.FullName(), false, true, "field removed, its number is reserved")
+		default:
+			c.add(prev.FullName(), true, true, "field removed without reserving its number")
+		}
+		return
+	}
+
+	if prev.Name() != field.Name() {
+		c.add(prev.FullName(), false, true, "field renamed to %v", field.Name())
+	}
+
+	if prevCard, nextCard := cardinality(prev), cardinality(field); prevCard != nextCard {
+		c.add(prev.FullName(), true, true, "field changed from %v to %v", prevCard, nextCard)
+		return
+	}
+
+	if prev.IsMap() {
+		c.checkKind(prev, prev.MapKey(), field.MapKey(), "map key ")
+		c.checkKind(prev, prev.MapValue(), field.MapValue(), "map value ")
+	} else {
+		c.checkKind(prev, prev, field, "")
+	}
+
+	if prev.Cardinality() != protoreflect.Required && field.Cardinality() == protoreflect.Required {
+		c.add(prev.FullName(), true, true, "field became required")
+	}
+
+	if prevOneOf, nextOneOf := oneOfName(prev), oneOfName(field); prevOneOf != nextOneOf {
+		c.add(prev.FullName(), true, true, "field moved from oneof %q to oneof %q", prevOneOf, nextOneOf)
+	}
+}
+
+func (c *checker) checkKind(field, prev, next protoreflect.FieldDescriptor, what string) {
+	prevType, nextType := typeName(prev), typeName(next)
+	if prevType == nextType {
+		return
+	}
+
+	wire := wireGroup(prev) != wireGroup(next)
+	json := jsonGroup(prev) != jsonGroup(next)
+	if wire || json {
+		c.add(field.FullName(), wire, json, "field %vtype changed from %v to %v", what, prevType, nextType)
+	}
+}
+
+func (c *checker) checkEnum(prev protoreflect.EnumDescriptor) {
+	c.visited[prev.FullName()] = true
+
+	next, ok := c.find(prev.FullName()).(protoreflect.EnumDescriptor)
+	if !ok {
+		c.add(prev.FullName(), true, true, "enum removed")
+		return
+	}
+
+	values := prev.Values()
+	for i := 0; i < values.Len(); i++ {
+		value := values.Get(i)
+		nextValue := next.Values().ByNumber(value.Number())
+
+		switch {
+		case nextValue == nil && next.ReservedRanges().Has(value.Number()):
+			c.add(value.FullName(), false, true, "enum value removed, its number is reserved")
+		case nextValue == nil:
+			c.add(value.FullName(), true, true, "enum value removed without reserving its number")
+		case nextValue.Name() != value.Name():
+			c.add(value.FullName(), false, true, "enum value renamed to %v", nextValue.Name())
+		}
+	}
+
+	nextValues := next.Values()
+	for i := 0; i < nextValues.Len(); i++ {
+		value := nextValues.Get(i)
+		if prev.ReservedRanges().Has(value.Number()) {
+			c.add(value.FullName(), true, false, "enum value uses number %v, reserved in previous schema", value.Number())
+		}
+		if prev.ReservedNames().Has(value.Name()) {
+			c.add(value.FullName(), false, true, "enum value uses name %v, reserved in previous schema", value.Name())
+		}
+	}
+}
+
+func cardinality(field protoreflect.FieldDescriptor) string {
+	switch {
+	case field.IsMap():
+		return "map"
+	case field.IsList():
+		return "list"
+	default:
+		return "singular"
+	}
+}
+
+func oneOfName(field protoreflect.FieldDescriptor) protoreflect.Name {
+	if oneOf := field.ContainingOneof(); oneOf != nil && !oneOf.IsSynthetic() {
+		return oneOf.Name()
+	}
+	return ""
+}
+
+func typeName(field protoreflect.FieldDescriptor) string {
+	switch {
+	case field.Message() != nil:
+		return string(field.Message().FullName())
+	case field.Enum() != nil:
+		return string(field.Enum().FullName())
+	default:
+		return field.Kind().String()
+	}
+}
+
+// wireGroup returns name of kinds group, which values are interchangeable in binary encoding.
+func wireGroup(field protoreflect.FieldDescriptor) string {
+	switch field.Kind() {
+	case protoreflect.Int32Kind, protoreflect.Int64Kind, protoreflect.Uint32Kind, protoreflect.Uint64Kind,
+		protoreflect.BoolKind, protoreflect.EnumKind:
+		return "varint"
+	case protoreflect.Sint32Kind, protoreflect.Sint64Kind:
+		return "zigzag"
+	case protoreflect.Fixed32Kind, protoreflect.Sfixed32Kind:
+		return "fixed32"
+	case protoreflect.Fixed64Kind, protoreflect.Sfixed64Kind:
+		return "fixed64"
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		return "bytes"
+	default:
+		return typeName(field)
+	}
+}
+
+// jsonGroup returns name of kinds group, which values are interchangeable in JSON and decoded maps.
+func jsonGroup(field protoreflect.FieldDescriptor) string {
+	switch field.Kind() {
+	case protoreflect.Int32Kind, protoreflect.Int64Kind, protoreflect.Uint32Kind, protoreflect.Uint64Kind,
+		protoreflect.Sint32Kind, protoreflect.Sint64Kind, protoreflect.Fixed32Kind, protoreflect.Fixed64Kind,
+		protoreflect.Sfixed32Kind, protoreflect.Sfixed64Kind:
+		return "integer"
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return "number"
+	default:
+		return typeName(field)
+	}
+}
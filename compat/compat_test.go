@@ -0,0 +1,81 @@
+package compat_test
+
+import (
+	"testing"
+
+	"github.com/gekatateam/protomap"
+	"github.com/gekatateam/protomap/compat"
+)
+
+func newMappers(t *testing.T) (*protomap.Mapper, *protomap.Mapper) {
+	prev, err := protomap.NewMapper(nil, "../testdata/compat/v1.proto")
+	if err != nil {
+		t.Fatalf("previous mapper creation failed: %v", err)
+	}
+
+	next, err := protomap.NewMapper(nil, "../testdata/compat/v2.proto")
+	if err != nil {
+		t.Fatalf("next mapper creation failed: %v", err)
+	}
+
+	return prev, next
+}
+
+func TestCheckMessage(t *testing.T) {
+	prev, next := newMappers(t)
+
+	changes, err := compat.CheckMessage(prev, next, "protomap.compat.Order")
+	if err != nil {
+		t.Fatalf("check failed: %v", err)
+	}
+
+	expected := []string{
+		"protomap.compat.Order.Tags: field changed from list to singular (wire, json)",
+		"protomap.compat.Order.Comment: field renamed to Remark (json)",
+		"protomap.compat.Order.Note: field number changed from 6 to 16 (wire)",
+		"protomap.compat.Order.Price: field type changed from double to string (wire, json)",
+		"protomap.compat.Order.Cash: field moved from oneof \"Payment\" to oneof \"\" (wire, json)",
+		"protomap.compat.Order.Legacy: field removed, its number is reserved (json)",
+		"protomap.compat.Order.Reused: field uses number 20, reserved in previous schema (wire)",
+		"protomap.compat.Order.Old: field uses name Old, reserved in previous schema (json)",
+		"protomap.compat.STATUS_NEW: enum value renamed to STATUS_CREATED (json)",
+		"protomap.compat.STATUS_FAILED: enum value removed, its number is reserved (json)",
+		"protomap.compat.Item.Data: field type changed from bytes to string (json)",
+	}
+
+	if len(changes) != len(expected) {
+		t.Fatalf("expected %v changes, got %v", len(expected), changes)
+	}
+
+	for i, change := range changes {
+		if change.String() != expected[i] {
+			t.Fatalf("expected %q, got %q", expected[i], change.String())
+		}
+	}
+
+	if _, err := compat.CheckMessage(prev, next, "protomap.compat.Unknown"); err == nil {
+		t.Fatal("expected error for unknown message")
+	}
+}
+
+func TestCheckFiles(t *testing.T) {
+	prev, next := newMappers(t)
+
+	changes := compat.CheckFiles(prev, next)
+	if len(changes) != 12 {
+		t.Fatalf("expected 12 changes, got %v", changes)
+	}
+
+	found := false
+	for _, change := range changes {
+		found = found || change.String() == "protomap.compat.Unused: message removed (wire, json)"
+	}
+
+	if !found {
+		t.Fatalf("expected removed message change, got %v", changes)
+	}
+
+	if changes := compat.CheckFiles(prev, prev); len(changes) != 0 {
+		t.Fatalf("expected no changes for the same schema, got %v", changes)
+	}
+}
@@ -0,0 +1,509 @@
+package protomap
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	fieldConstraintsOption   = "buf.validate.field"
+	messageConstraintsOption = "buf.validate.message"
+	oneofConstraintsOption   = "buf.validate.oneof"
+)
+
+var (
+	hostnameRegexp = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.)*[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+	uuidRegexp     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
+// patterns holds compiled pattern rules, or compilation errors, by pattern.
+var patterns sync.Map
+
+type compiledPattern struct {
+	re  *regexp.Regexp
+	err error
+}
+
+// CheckConstraints evaluates common buf.validate (protovalidate) standard rules set in message descriptor options
+// and reports all violations as *ValidationError; paths are the same as in Validate.
+// Supported rules are field required, scalar, enum, repeated and map rules, oneof required and message disabled;
+// CEL expressions and well-known type rules are ignored.
+func CheckConstraints(message protoreflect.Message) error {
+	var c constraintChecker
+	c.checkMessage(message, "")
+
+	if len(c.violations) > 0 {
+		return &ValidationError{Violations: c.violations}
+	}
+	return nil
+}
+
+// WithConstraints returns Mapper copy that checks message constraints with CheckConstraints
+// after encoding and before decoding.
+func (m *Mapper) WithConstraints() *Mapper {
+	mapper := *m
+	mapper.constraints = true
+	return &mapper
+}
+
+type constraintChecker struct {
+	violations  []Violation
+	badPatterns map[string]struct{}
+}
+
+func (c *constraintChecker) add(at string, rule string, format string, args ...any) {
+	c.violations = append(c.violations, Violation{Path: at, Reason: rule + ": " + fmt.Sprintf(format, args...)})
+}
+
+func (c *constraintChecker) checkMessage(message protoreflect.Message, at string) {
+	desc := message.Descriptor()
+	if rules, ok := findRules(desc, messageConstraintsOption); ok && ruleBool(rules, "disabled") {
+		return
+	}
+
+	oneOfs := desc.Oneofs()
+	for i := 0; i < oneOfs.Len(); i++ {
+		oneOf := oneOfs.Get(i)
+		if rules, ok := findRules(oneOf, oneofConstraintsOption); ok && ruleBool(rules, "required") && message.WhichOneof(oneOf) == nil {
+			c.add(joinPath(at, oneOf.Name()), "required", "exactly one field is required in oneof")
+		}
+	}
+
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		fieldAt := joinPath(at, field.Name())
+		rules, hasRules := findRules(field, fieldConstraintsOption)
+
+		if !message.Has(field) {
+			if hasRules && ruleBool(rules, "required") {
+				c.add(fieldAt, "required", "value is required")
+				continue
+			}
+
+			// rules of fields without presence are applied to zero values too
+			if !hasRules || field.HasPresence() || field.IsList() || field.IsMap() {
+				continue
+			}
+		}
+
+		value := message.Get(field)
+
+		if field.IsList() {
+			var items protoreflect.Message
+			if repeated, ok := typeRules(rules, "repeated"); ok {
+				c.checkRepeated(repeated, field, value.List(), fieldAt)
+				items, _ = ruleMessage(repeated, "items")
+			}
+
+			list := value.List()
+			for j := 0; j < list.Len(); j++ {
+				c.checkValue(field, field.Kind(), list.Get(j), items, fmt.Sprintf("%v[%v]", fieldAt, j))
+			}
+			continue
+		}
+
+		if field.IsMap() {
+			var keys, values protoreflect.Message
+			pmap := value.Map()
+			if mapRules, ok := typeRules(rules, "map"); ok {
+				c.checkMap(mapRules, pmap.Len(), fieldAt)
+				keys, _ = ruleMessage(mapRules, "keys")
+				values, _ = ruleMessage(mapRules, "values")
+			}
+
+			pmap.Range(func(mk protoreflect.MapKey, v protoreflect.Value) bool {
+				elemAt := fmt.Sprintf("%v['%v']", fieldAt, mk.String())
+				c.checkValue(field.MapKey(), field.MapKey().Kind(), mk.Value(), keys, elemAt)
+				c.checkValue(field.MapValue(), field.MapValue().Kind(), v, values, elemAt)
+				return true
+			})
+			continue
+		}
+
+		c.checkValue(field, field.Kind(), value, rules, fieldAt)
+	}
+}
+
+// checkValue checks single value against field constraints, which may be nil, and nested message constraints.
+func (c *constraintChecker) checkValue(field protoreflect.FieldDescriptor, kind protoreflect.Kind, value protoreflect.Value, rules protoreflect.Message, at string) {
+	if kind == protoreflect.MessageKind || kind == protoreflect.GroupKind {
+		if value.Message().IsValid() {
+			c.checkMessage(value.Message(), at)
+		}
+		return
+	}
+
+	if rules == nil {
+		return
+	}
+
+	typeField := rules.WhichOneof(rules.Descriptor().Oneofs().ByName("type"))
+	if typeField == nil {
+		return
+	}
+
+	typed := rules.Get(typeField).Message()
+	switch name := string(typeField.Name()); name {
+	case "string":
+		c.checkString(typed, value.String(), at)
+	case "bytes":
+		c.checkBytes(typed, value.Bytes(), at)
+	case "bool":
+		if v, ok := ruleValue(typed, "const"); ok && v.Bool() != value.Bool() {
+			c.add(at, "bool.const", "value must equal %v", v.Bool())
+		}
+	case "enum":
+		c.checkEnum(typed, field, value.Enum(), at)
+	case "float", "double", "int32", "int64", "uint32", "uint64",
+		"sint32", "sint64", "fixed32", "fixed64", "sfixed32", "sfixed64":
+		c.checkNumber(typed, name, value, at)
+	}
+}
+
+func (c *constraintChecker) checkString(rules protoreflect.Message, s string, at string) {
+	if v, ok := ruleValue(rules, "const"); ok && s != v.String() {
+		c.add(at, "string.const", "value must equal %q", v.String())
+	}
+
+	length := uint64(utf8.RuneCountInString(s))
+	if v, ok := ruleValue(rules, "len"); ok && length != v.Uint() {
+		c.add(at, "string.len", "value length must be %v characters", v.Uint())
+	}
+	if v, ok := ruleValue(rules, "min_len"); ok && length < v.Uint() {
+		c.add(at, "string.min_len", "value length must be at least %v characters", v.Uint())
+	}
+	if v, ok := ruleValue(rules, "max_len"); ok && length > v.Uint() {
+		c.add(at, "string.max_len", "value length must be at most %v characters", v.Uint())
+	}
+	if v, ok := ruleValue(rules, "len_bytes"); ok && uint64(len(s)) != v.Uint() {
+		c.add(at, "string.len_bytes", "value length must be %v bytes", v.Uint())
+	}
+	if v, ok := ruleValue(rules, "min_bytes"); ok && uint64(len(s)) < v.Uint() {
+		c.add(at, "string.min_bytes", "value length must be at least %v bytes", v.Uint())
+	}
+	if v, ok := ruleValue(rules, "max_bytes"); ok && uint64(len(s)) > v.Uint() {
+		c.add(at, "string.max_bytes", "value length must be at most %v bytes", v.Uint())
+	}
+
+	if v, ok := ruleValue(rules, "pattern"); ok {
+		c.checkPattern("string.pattern", v.String(), s, at)
+	}
+	if v, ok := ruleValue(rules, "prefix"); ok && !strings.HasPrefix(s, v.String()) {
+		c.add(at, "string.prefix", "value does not have prefix %q", v.String())
+	}
+	if v, ok := ruleValue(rules, "suffix"); ok && !strings.HasSuffix(s, v.String()) {
+		c.add(at, "string.suffix", "value does not have suffix %q", v.String())
+	}
+	if v, ok := ruleValue(rules, "contains"); ok && !strings.Contains(s, v.String()) {
+		c.add(at, "string.contains", "value does not contain substring %q", v.String())
+	}
+	if v, ok := ruleValue(rules, "not_contains"); ok && strings.Contains(s, v.String()) {
+		c.add(at, "string.not_contains", "value contains substring %q", v.String())
+	}
+
+	c.checkIn("string", rules, protoreflect.ValueOfString(s), at)
+
+	switch {
+	case ruleBool(rules, "email"):
+		if addr, err := mail.ParseAddress(s); err != nil || addr.Address != s {
+			c.add(at, "string.email", "value must be a valid email address")
+		}
+	case ruleBool(rules, "hostname"):
+		if len(s) > 253 || !hostnameRegexp.MatchString(s) {
+			c.add(at, "string.hostname", "value must be a valid hostname")
+		}
+	case ruleBool(rules, "ip"):
+		if _, err := netip.ParseAddr(s); err != nil {
+			c.add(at, "string.ip", "value must be a valid IP address")
+		}
+	case ruleBool(rules, "ipv4"):
+		if addr, err := netip.ParseAddr(s); err != nil || !addr.Is4() {
+			c.add(at, "string.ipv4", "value must be a valid IPv4 address")
+		}
+	case ruleBool(rules, "ipv6"):
+		if addr, err := netip.ParseAddr(s); err != nil || !addr.Is6() {
+			c.add(at, "string.ipv6", "value must be a valid IPv6 address")
+		}
+	case ruleBool(rules, "uri"):
+		if u, err := url.Parse(s); err != nil || u.Scheme == "" {
+			c.add(at, "string.uri", "value must be a valid absolute URI")
+		}
+	case ruleBool(rules, "uuid"):
+		if !uuidRegexp.MatchString(s) {
+			c.add(at, "string.uuid", "value must be a valid UUID")
+		}
+	}
+}
+
+func (c *constraintChecker) checkBytes(rules protoreflect.Message, b []byte, at string) {
+	if v, ok := ruleValue(rules, "const"); ok && !bytes.Equal(b, v.Bytes()) {
+		c.add(at, "bytes.const", "value must equal %x", v.Bytes())
+	}
+	if v, ok := ruleValue(rules, "len"); ok && uint64(len(b)) != v.Uint() {
+		c.add(at, "bytes.len", "value length must be %v bytes", v.Uint())
+	}
+	if v, ok := ruleValue(rules, "min_len"); ok && uint64(len(b)) < v.Uint() {
+		c.add(at, "bytes.min_len", "value length must be at least %v bytes", v.Uint())
+	}
+	if v, ok := ruleValue(rules, "max_len"); ok && uint64(len(b)) > v.Uint() {
+		c.add(at, "bytes.max_len", "value length must be at most %v bytes", v.Uint())
+	}
+	if v, ok := ruleValue(rules, "pattern"); ok {
+		c.checkPattern("bytes.pattern", v.String(), string(b), at)
+	}
+	if v, ok := ruleValue(rules, "prefix"); ok && !bytes.HasPrefix(b, v.Bytes()) {
+		c.add(at, "bytes.prefix", "value does not have prefix %x", v.Bytes())
+	}
+	if v, ok := ruleValue(rules, "suffix"); ok && !bytes.HasSuffix(b, v.Bytes()) {
+		c.add(at, "bytes.suffix", "value does not have suffix %x", v.Bytes())
+	}
+	if v, ok := ruleValue(rules, "contains"); ok && !bytes.Contains(b, v.Bytes()) {
+		c.add(at, "bytes.contains", "value does not contain %x", v.Bytes())
+	}
+
+	c.checkIn("bytes", rules, protoreflect.ValueOfBytes(b), at)
+}
+
+func (c *constraintChecker) checkEnum(rules protoreflect.Message, field protoreflect.FieldDescriptor, n protoreflect.EnumNumber, at string) {
+	if v, ok := ruleValue(rules, "const"); ok && int64(n) != v.Int() {
+		c.add(at, "enum.const", "value must equal %v", v.Int())
+	}
+	if ruleBool(rules, "defined_only") && field.Enum().Values().ByNumber(n) == nil {
+		c.add(at, "enum.defined_only", "value must be one of the defined enum values")
+	}
+
+	c.checkIn("enum", rules, protoreflect.ValueOfInt32(int32(n)), at)
+}
+
+func (c *constraintChecker) checkNumber(rules protoreflect.Message, name string, value protoreflect.Value, at string) {
+	if v, ok := ruleValue(rules, "const"); ok && !equalValues(value, v) {
+		c.add(at, name+".const", "value must equal %v", v.Interface())
+	}
+
+	if ruleBool(rules, "finite") {
+		if f := value.Float(); math.IsInf(f, 0) || math.IsNaN(f) {
+			c.add(at, name+".finite", "value must be finite")
+		}
+	}
+
+	var rule []string
+	var bounds []string
+	var lower, upper protoreflect.Value
+	lowerOk, upperOk := true, true
+
+	if v, ok := ruleValue(rules, "gt"); ok {
+		result, comparable := compareValues(value, v)
+		lower, lowerOk = v, comparable && result > 0
+		rule, bounds = append(rule, "gt"), append(bounds, fmt.Sprintf("greater than %v", v.Interface()))
+	}
+	if v, ok := ruleValue(rules, "gte"); ok {
+		result, comparable := compareValues(value, v)
+		lower, lowerOk = v, comparable && result >= 0
+		rule, bounds = append(rule, "gte"), append(bounds, fmt.Sprintf("greater than or equal to %v", v.Interface()))
+	}
+	if v, ok := ruleValue(rules, "lt"); ok {
+		result, comparable := compareValues(value, v)
+		upper, upperOk = v, comparable && result < 0
+		rule, bounds = append(rule, "lt"), append(bounds, fmt.Sprintf("less than %v", v.Interface()))
+	}
+	if v, ok := ruleValue(rules, "lte"); ok {
+		result, comparable := compareValues(value, v)
+		upper, upperOk = v, comparable && result <= 0
+		rule, bounds = append(rule, "lte"), append(bounds, fmt.Sprintf("less than or equal to %v", v.Interface()))
+	}
+
+	// lower bound above upper one means value must be outside of range
+	exclusive := false
+	if lower.IsValid() && upper.IsValid() {
+		result, _ := compareValues(lower, upper)
+		exclusive = result > 0
+	}
+
+	if exclusive {
+		if !lowerOk && !upperOk {
+			c.add(at, name+"."+strings.Join(rule, "_"), "value must be %v", strings.Join(bounds, " or "))
+		}
+	} else if !lowerOk || !upperOk {
+		c.add(at, name+"."+strings.Join(rule, "_"), "value must be %v", strings.Join(bounds, " and "))
+	}
+
+	c.checkIn(name, rules, value, at)
+}
+
+func (c *constraintChecker) checkIn(name string, rules protoreflect.Message, value protoreflect.Value, at string) {
+	if v, ok := ruleValue(rules, "in"); ok && !listContains(v.List(), value) {
+		c.add(at, name+".in", "value must be in list %v", listString(v.List()))
+	}
+	if v, ok := ruleValue(rules, "not_in"); ok && listContains(v.List(), value) {
+		c.add(at, name+".not_in", "value must not be in list %v", listString(v.List()))
+	}
+}
+
+func (c *constraintChecker) checkPattern(rule string, pattern string, s string, at string) {
+	compiled, ok := patterns.Load(pattern)
+	if !ok {
+		re, err := regexp.Compile(pattern)
+		compiled, _ = patterns.LoadOrStore(pattern, compiledPattern{re: re, err: err})
+	}
+
+	p := compiled.(compiledPattern)
+	if p.err != nil {
+		// invalid pattern is a schema error, so it is reported once, not for every value
+		if _, ok := c.badPatterns[pattern]; !ok {
+			if c.badPatterns == nil {
+				c.badPatterns = make(map[string]struct{})
+			}
+			c.badPatterns[pattern] = struct{}{}
+			c.add(at, rule, "invalid pattern %q: %v", pattern, p.err)
+		}
+		return
+	}
+
+	if !p.re.MatchString(s) {
+		c.add(at, rule, "value does not match pattern %q", pattern)
+	}
+}
+
+func (c *constraintChecker) checkRepeated(rules protoreflect.Message, field protoreflect.FieldDescriptor, list protoreflect.List, at string) {
+	if v, ok := ruleValue(rules, "min_items"); ok && uint64(list.Len()) < v.Uint() {
+		c.add(at, "repeated.min_items", "value must contain at least %v item(s)", v.Uint())
+	}
+	if v, ok := ruleValue(rules, "max_items"); ok && uint64(list.Len()) > v.Uint() {
+		c.add(at, "repeated.max_items", "value must contain no more than %v item(s)", v.Uint())
+	}
+
+	if ruleBool(rules, "unique") && field.Message() == nil {
+		seen := make(map[any]struct{}, list.Len())
+		for i := 0; i < list.Len(); i++ {
+			key := list.Get(i).Interface()
+			if b, ok := key.([]byte); ok {
+				key = string(b)
+			}
+
+			if _, ok := seen[key]; ok {
+				c.add(at, "repeated.unique", "repeated value must contain unique items")
+				return
+			}
+			seen[key] = struct{}{}
+		}
+	}
+}
+
+func (c *constraintChecker) checkMap(rules protoreflect.Message, size int, at string) {
+	if v, ok := ruleValue(rules, "min_pairs"); ok && uint64(size) < v.Uint() {
+		c.add(at, "map.min_pairs", "map must be at least %v entries", v.Uint())
+	}
+	if v, ok := ruleValue(rules, "max_pairs"); ok && uint64(size) > v.Uint() {
+		c.add(at, "map.max_pairs", "map must be at most %v entries", v.Uint())
+	}
+}
+
+func findRules(desc protoreflect.Descriptor, optionName string) (protoreflect.Message, bool) {
+	v, ok := FindOption(desc, optionName)
+	if !ok {
+		return nil, false
+	}
+
+	m, ok := v.Interface().(protoreflect.Message)
+	return m, ok
+}
+
+func typeRules(rules protoreflect.Message, name string) (protoreflect.Message, bool) {
+	if rules == nil {
+		return nil, false
+	}
+	return ruleMessage(rules, name)
+}
+
+func ruleMessage(rules protoreflect.Message, name string) (protoreflect.Message, bool) {
+	v, ok := ruleValue(rules, name)
+	if !ok {
+		return nil, false
+	}
+
+	m, ok := v.Interface().(protoreflect.Message)
+	return m, ok
+}
+
+func ruleValue(rules protoreflect.Message, name string) (protoreflect.Value, bool) {
+	fd := rules.Descriptor().Fields().ByName(protoreflect.Name(name))
+	if fd == nil || !rules.Has(fd) {
+		return protoreflect.Value{}, false
+	}
+	return rules.Get(fd), true
+}
+
+func ruleBool(rules protoreflect.Message, name string) bool {
+	v, ok := ruleValue(rules, name)
+	if !ok {
+		return false
+	}
+
+	b, _ := v.Interface().(bool)
+	return b
+}
+
+// compareValues compares values of the same kind category; NaN is not comparable to anything.
+func compareValues(a, b protoreflect.Value) (result int, ok bool) {
+	switch a.Interface().(type) {
+	case int32, int64:
+		return compareOrdered(a.Int(), b.Int()), true
+	case uint32, uint64:
+		return compareOrdered(a.Uint(), b.Uint()), true
+	case float32, float64:
+		if math.IsNaN(a.Float()) || math.IsNaN(b.Float()) {
+			return 0, false
+		}
+		return compareOrdered(a.Float(), b.Float()), true
+	case string:
+		return strings.Compare(a.String(), b.String()), true
+	case []byte:
+		return bytes.Compare(a.Bytes(), b.Bytes()), true
+	default:
+		return 0, false
+	}
+}
+
+func equalValues(a, b protoreflect.Value) bool {
+	result, ok := compareValues(a, b)
+	return ok && result == 0
+}
+
+func compareOrdered[T int64 | uint64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func listContains(list protoreflect.List, value protoreflect.Value) bool {
+	for i := 0; i < list.Len(); i++ {
+		if equalValues(value, list.Get(i)) {
+			return true
+		}
+	}
+	return false
+}
+
+func listString(list protoreflect.List) string {
+	items := make([]string, 0, list.Len())
+	for i := 0; i < list.Len(); i++ {
+		items = append(items, fmt.Sprint(list.Get(i).Interface()))
+	}
+	return "[" + strings.Join(items, ", ") + "]"
+}
@@ -0,0 +1,173 @@
+package protomap_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/gekatateam/protomap"
+)
+
+func TestConstraints_EncodeAndDecode(t *testing.T) {
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{ImportPaths: []string{"./testdata"}}),
+	}
+
+	plain, err := protomap.NewMapper(&compiler, "constraints.proto")
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+	mapper := plain.WithConstraints()
+
+	valid := map[string]any{
+		"Name":   "alice",
+		"Email":  "alice@example.com",
+		"Id":     "123e4567-e89b-12d3-a456-426614174000",
+		"Age":    30,
+		"Score":  0.5,
+		"Role":   "admin",
+		"Tags":   []any{"a", "b"},
+		"Limits": map[string]any{"cpu": 2},
+		"Status": "STATUS_ACTIVE",
+		"Inner":  map[string]any{"Token": []byte("abcd")},
+		"Phone":  "+123",
+	}
+
+	binary, err := mapper.Encode(valid, "protomap.test.WithConstraints")
+	if err != nil {
+		t.Fatalf("valid input encoding failed: %v", err)
+	}
+
+	if _, err := mapper.Decode(binary, "protomap.test.WithConstraints"); err != nil {
+		t.Fatalf("valid binary decoding failed: %v", err)
+	}
+
+	invalid := map[string]any{
+		"Name":     "Al",
+		"Email":    "alice",
+		"Id":       "123",
+		"Age":      17,
+		"Score":    0,
+		"Role":     "root",
+		"Tags":     []any{"a", "a", ""},
+		"Limits":   map[string]any{"cpu": -1, "mem": 1, "disk": 1},
+		"Priority": 6,
+		"Phone":    "123",
+	}
+
+	_, err = mapper.Encode(invalid, "protomap.test.WithConstraints")
+
+	var verr *protomap.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+
+	var reasons []string
+	for _, v := range verr.Violations {
+		rule, _, _ := strings.Cut(v.Reason, ":")
+		reasons = append(reasons, v.Path+" "+rule)
+	}
+
+	expected := []string{
+		"Name string.min_len",
+		"Name string.pattern",
+		"Email string.email",
+		"Id string.uuid",
+		"Age int32.gte_lt",
+		"Score double.gt_lte",
+		"Role string.in",
+		"Tags repeated.unique",
+		"Tags[2] string.min_len",
+		"Limits map.max_pairs",
+		"Limits['cpu'] int64.gte",
+		"Inner required",
+		"Priority uint32.lte",
+		"Phone string.prefix",
+	}
+
+	if !reflect.DeepEqual(expected, reasons) {
+		t.Fatalf("expected %v, got %v", expected, reasons)
+	}
+
+	binary, err = plain.Encode(invalid, "protomap.test.WithConstraints")
+	if err != nil {
+		t.Fatalf("invalid input encoding without constraints failed: %v", err)
+	}
+
+	if _, err := mapper.Decode(binary, "protomap.test.WithConstraints"); !errors.As(err, &verr) {
+		t.Fatalf("expected ValidationError on decoding, got %v", err)
+	}
+}
+
+func TestConstraints_SetByPath(t *testing.T) {
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{ImportPaths: []string{"./testdata"}}),
+	}
+
+	plain, err := protomap.NewMapper(&compiler, "constraints.proto")
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+	mapper := plain.WithConstraints()
+
+	valid := map[string]any{
+		"Name":   "alice",
+		"Tags":   []any{"a"},
+		"Inner":  map[string]any{"Token": []byte("abcd")},
+		"Phone":  "+123",
+		"Age":    30,
+		"Score":  0.5,
+		"Role":   "user",
+		"Email":  "alice@example.com",
+		"Id":     "123e4567-e89b-12d3-a456-426614174000",
+		"Limits": map[string]any{},
+		"Status": "STATUS_ACTIVE",
+	}
+
+	binary, err := plain.Encode(valid, "protomap.test.WithConstraints")
+	if err != nil {
+		t.Fatalf("valid input encoding failed: %v", err)
+	}
+
+	var verr *protomap.ValidationError
+	for _, data := range []any{valid, binary} {
+		if _, err := mapper.Set("protomap.test.WithConstraints", data, "Age", 17); !errors.As(err, &verr) {
+			t.Fatalf("expected ValidationError for %T data, got %v", data, err)
+		}
+	}
+
+	invalid, err := plain.Set("protomap.test.WithConstraints", binary, "Name", "Al")
+	if err != nil {
+		t.Fatalf("set without constraints failed: %v", err)
+	}
+
+	if _, err := mapper.Set("protomap.test.WithConstraints", invalid, "Name", "bob"); err != nil {
+		t.Fatalf("expected invalid data fixed by set, got %v", err)
+	}
+}
+
+func TestConstraints_BadPattern(t *testing.T) {
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{ImportPaths: []string{"./testdata"}}),
+	}
+
+	mapper, err := protomap.NewMapper(&compiler, "constraints.proto")
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err = mapper.WithConstraints().Encode(map[string]any{"Codes": []any{"a", "b", "c"}}, "protomap.test.WithBadPattern")
+
+		var verr *protomap.ValidationError
+		if !errors.As(err, &verr) {
+			t.Fatalf("expected ValidationError, got %v", err)
+		}
+
+		if len(verr.Violations) != 1 || verr.Violations[0].Path != "Codes[0]" {
+			t.Fatalf("expected single invalid pattern violation, got %v", verr)
+		}
+	}
+}
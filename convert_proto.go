@@ -9,8 +9,25 @@ import (
 
 type DecodeInterceptor func(message protoreflect.Message) (result any, applied bool, err error)
 
+// FieldDecodeInterceptor decodes single value of field; for lists and maps it is called for every element.
+type FieldDecodeInterceptor func(field protoreflect.FieldDescriptor, value protoreflect.Value) (result any, applied bool, err error)
+
+type decoder struct {
+	inters  []DecodeInterceptor
+	fields  []fieldInterceptor
+	options *optionsCache
+}
+
 func MessageToAny(message protoreflect.Message, inters ...DecodeInterceptor) (any, error) {
-	for _, i := range inters {
+	return decoder{inters: inters}.messageToAny(message, "")
+}
+
+func ProtoToGoValue(desc protoreflect.FieldDescriptor, kind protoreflect.Kind, value protoreflect.Value, inters ...DecodeInterceptor) (any, error) {
+	return decoder{inters: inters}.protoToGoValue(desc, kind, value, "")
+}
+
+func (d decoder) messageToAny(message protoreflect.Message, path string) (any, error) {
+	for _, i := range d.inters {
 		val, applied, err := i(message)
 		if err != nil {
 			return nil, err
@@ -23,9 +40,15 @@ func MessageToAny(message protoreflect.Message, inters ...DecodeInterceptor) (an
 
 	fields := message.Descriptor().Fields()
 	result := make(map[string]any, fields.Len())
+	omitEmpty := d.options.get(message.Descriptor()).omitEmpty
 
 	for i := 0; i < fields.Len(); i++ {
 		field := fields.Get(i)
+		fieldPath := joinPath(path, field.Name())
+
+		if d.options.get(field).skip || (omitEmpty && !message.Has(field)) {
+			continue
+		}
 
 		if oneOf := field.ContainingOneof(); oneOf != nil {
 			if oneOfField := message.WhichOneof(oneOf); oneOfField != nil {
@@ -39,7 +62,7 @@ func MessageToAny(message protoreflect.Message, inters ...DecodeInterceptor) (an
 			list := message.Get(field).List()
 			slice := make([]any, 0, list.Len())
 			for j := 0; j < list.Len(); j++ {
-				value, err := ProtoToGoValue(field, field.Kind(), list.Get(j), inters...)
+				value, err := d.protoToGoValue(field, field.Kind(), list.Get(j), fieldPath)
 				if err != nil {
 					return nil, fmt.Errorf("%v.%v: %w", string(field.Name()), j, err)
 				}
@@ -58,7 +81,7 @@ func MessageToAny(message protoreflect.Message, inters ...DecodeInterceptor) (an
 			var err error
 			var failedKey string
 			pmap.Range(func(mk protoreflect.MapKey, v protoreflect.Value) bool {
-				value, convertErr := ProtoToGoValue(field, mapvaluekind, v, inters...)
+				value, convertErr := d.protoToGoValue(field, mapvaluekind, v, fieldPath)
 				if convertErr != nil {
 					err = convertErr
 					failedKey = mk.String()
@@ -77,7 +100,7 @@ func MessageToAny(message protoreflect.Message, inters ...DecodeInterceptor) (an
 			continue
 		}
 
-		value, err := ProtoToGoValue(field, field.Kind(), message.Get(field), inters...)
+		value, err := d.protoToGoValue(field, field.Kind(), message.Get(field), fieldPath)
 		if err != nil {
 			return nil, fmt.Errorf("%v: %w", string(field.Name()), err)
 		}
@@ -87,7 +110,26 @@ func MessageToAny(message protoreflect.Message, inters ...DecodeInterceptor) (an
 	return result, nil
 }
 
-func ProtoToGoValue(desc protoreflect.FieldDescriptor, kind protoreflect.Kind, value protoreflect.Value, inters ...DecodeInterceptor) (any, error) {
+func (d decoder) protoToGoValue(desc protoreflect.FieldDescriptor, kind protoreflect.Kind, value protoreflect.Value, path string) (any, error) {
+	for _, f := range d.fields {
+		if f.dec == nil || !f.selector(desc, path) {
+			continue
+		}
+
+		result, applied, err := f.dec(desc, value)
+		if err != nil {
+			return nil, err
+		}
+
+		if applied {
+			return result, nil
+		}
+	}
+
+	if opts := d.options.get(desc); opts.hasFormat {
+		return decodeFormat(opts.format, kind, value)
+	}
+
 	switch kind {
 	case protoreflect.BoolKind:
 		return value.Bool(), nil
@@ -104,9 +146,13 @@ func ProtoToGoValue(desc protoreflect.FieldDescriptor, kind protoreflect.Kind, v
 	case protoreflect.BytesKind:
 		return value.Bytes(), nil
 	case protoreflect.EnumKind:
-		return string(desc.Enum().Values().ByNumber(value.Enum()).Name()), nil
+		enumDesc := desc.Enum()
+		if desc.IsMap() {
+			enumDesc = desc.MapValue().Enum()
+		}
+		return string(enumDesc.Values().ByNumber(value.Enum()).Name()), nil
 	case protoreflect.MessageKind, protoreflect.GroupKind:
-		return MessageToAny(value.Message(), inters...)
+		return d.messageToAny(value.Message(), path)
 	default:
 		return nil, fmt.Errorf("unsupported field type: %s", kind)
 	}
@@ -114,8 +160,25 @@ func ProtoToGoValue(desc protoreflect.FieldDescriptor, kind protoreflect.Kind, v
 
 type EncodeInterceptor func(input any, message protoreflect.Message) (applied bool, err error)
 
+// FieldEncodeInterceptor encodes single value of field; for lists and maps it is called for every element.
+type FieldEncodeInterceptor func(field protoreflect.FieldDescriptor, input any) (value protoreflect.Value, applied bool, err error)
+
+type encoder struct {
+	inters  []EncodeInterceptor
+	fields  []fieldInterceptor
+	options *optionsCache
+}
+
 func AnyToMessage(input any, message protoreflect.Message, inters ...EncodeInterceptor) error {
-	for _, i := range inters {
+	return encoder{inters: inters}.anyToMessage(input, message, "")
+}
+
+func GoValueToProto(desc protoreflect.FieldDescriptor, kind protoreflect.Kind, value any, inters ...EncodeInterceptor) (protoreflect.Value, error) {
+	return encoder{inters: inters}.goValueToProto(desc, kind, value, "")
+}
+
+func (e encoder) anyToMessage(input any, message protoreflect.Message, path string) error {
+	for _, i := range e.inters {
 		applied, err := i(input, message)
 		if err != nil {
 			return err
@@ -126,79 +189,144 @@ func AnyToMessage(input any, message protoreflect.Message, inters ...EncodeInter
 		}
 	}
 
+	// nil input leaves message empty
+	if input == nil {
+		return nil
+	}
+
 	data, ok := input.(map[string]any)
 	if !ok {
 		return fmt.Errorf("expected map[string]any, got %T", input)
 	}
 
 	fields := message.Descriptor().Fields()
+	omitEmpty := e.options.get(message.Descriptor()).omitEmpty
 
 	for i := 0; i < fields.Len(); i++ {
 		field := fields.Get(i)
+		fieldPath := joinPath(path, field.Name())
+
+		opts := e.options.get(field)
+		if opts.skip {
+			continue
+		}
 
 		value, ok := data[string(field.Name())]
 		if !ok {
-			if field.Cardinality() == protoreflect.Optional {
+			switch {
+			case opts.hasDefault && !field.IsList() && !field.IsMap():
+				value = opts.def
+			case field.Cardinality() == protoreflect.Optional || omitEmpty:
 				continue
+			default:
+				return fmt.Errorf("%v is not optional, but input data has no such key", field.Name())
 			}
-			return fmt.Errorf("%v is not optional, but input data has no such key", field.Name())
 		}
 
 		if field.IsList() {
-			slice, ok := value.([]any)
-			if !ok {
-				return fmt.Errorf("%v is a list, but input data field is not a slice", field.Name())
-			}
-
-			elemkind := field.Kind()
-			protolist := message.Mutable(field).List()
-			for i, v := range slice {
-				protovalue, err := GoValueToProto(field, elemkind, v, inters...)
-				if err != nil {
-					return fmt.Errorf("%v.%v: %w", field.Name(), i, err)
-				}
-				protolist.Append(protovalue)
+			if err := e.fillList(field, value, message.Mutable(field).List(), fieldPath); err != nil {
+				return err
 			}
 			continue
 		}
 
 		if field.IsMap() {
-			gomap, ok := value.(map[string]any)
-			if !ok {
-				return fmt.Errorf("%v is a map, but input data field is not a map", field.Name())
-			}
-
-			keykind := field.MapKey().Kind()
-			valkind := field.MapValue().Kind()
-			protomap := message.Mutable(field).Map()
-			for k, v := range gomap {
-				protokey, err := GoValueToProto(field, keykind, k, inters...)
-				if err != nil {
-					return fmt.Errorf("%v.%v key: %w", field.Name(), k, err)
-				}
-
-				protovalue, err := GoValueToProto(field, valkind, v, inters...)
-				if err != nil {
-					return fmt.Errorf("%v.%v key: %w", field.Name(), k, err)
-				}
-
-				protomap.Set(protokey.MapKey(), protovalue)
+			if err := e.fillMap(field, value, message.Mutable(field).Map(), fieldPath); err != nil {
+				return err
 			}
 			continue
 		}
 
-		protovalue, err := GoValueToProto(field, field.Kind(), value, inters...)
+		protovalue, err := e.goValueToProto(field, field.Kind(), value, fieldPath)
 		if err != nil {
 			return fmt.Errorf("%v: %w", field.Name(), err)
 		}
 
+		// nil message field is kept absent, unless interceptor populated it
+		if value == nil && field.Message() != nil && !isPopulated(protovalue.Message()) {
+			continue
+		}
+
 		message.Set(field, protovalue)
 	}
 
 	return nil
 }
 
-func GoValueToProto(desc protoreflect.FieldDescriptor, kind protoreflect.Kind, value any, inters ...EncodeInterceptor) (protoreflect.Value, error) {
+// fillList appends slice elements to list of field.
+func (e encoder) fillList(field protoreflect.FieldDescriptor, value any, protolist protoreflect.List, path string) error {
+	slice, ok := value.([]any)
+	if !ok {
+		return fmt.Errorf("%v is a list, but input data field is not a slice", field.Name())
+	}
+
+	elemkind := field.Kind()
+	for i, v := range slice {
+		protovalue, err := e.goValueToProto(field, elemkind, v, path)
+		if err != nil {
+			return fmt.Errorf("%v.%v: %w", field.Name(), i, err)
+		}
+		protolist.Append(protovalue)
+	}
+	return nil
+}
+
+// fillMap sets map entries to map of field.
+func (e encoder) fillMap(field protoreflect.FieldDescriptor, value any, protomap protoreflect.Map, path string) error {
+	gomap, ok := value.(map[string]any)
+	if !ok {
+		return fmt.Errorf("%v is a map, but input data field is not a map", field.Name())
+	}
+
+	keykind := field.MapKey().Kind()
+	valkind := field.MapValue().Kind()
+	for k, v := range gomap {
+		protokey, err := encoder{}.kindToProto(field, keykind, k, path)
+		if err != nil {
+			return fmt.Errorf("%v.%v key: %w", field.Name(), k, err)
+		}
+
+		protovalue, err := e.goValueToProto(field, valkind, v, path)
+		if err != nil {
+			return fmt.Errorf("%v.%v key: %w", field.Name(), k, err)
+		}
+
+		protomap.Set(protokey.MapKey(), protovalue)
+	}
+	return nil
+}
+
+func (e encoder) goValueToProto(desc protoreflect.FieldDescriptor, kind protoreflect.Kind, value any, path string) (protoreflect.Value, error) {
+	for _, f := range e.fields {
+		if f.enc == nil || !f.selector(desc, path) {
+			continue
+		}
+
+		result, applied, err := f.enc(desc, value)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+
+		if applied {
+			return result, nil
+		}
+	}
+
+	if opts := e.options.get(desc); opts.hasFormat {
+		result, applied, err := encodeFormat(opts.format, value)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+
+		if applied {
+			value = result
+		}
+	}
+
+	return e.kindToProto(desc, kind, value, path)
+}
+
+func (e encoder) kindToProto(desc protoreflect.FieldDescriptor, kind protoreflect.Kind, value any, path string) (protoreflect.Value, error) {
 	switch kind {
 	case protoreflect.StringKind:
 		v, err := AnyToString(value)
@@ -255,8 +383,13 @@ func GoValueToProto(desc protoreflect.FieldDescriptor, kind protoreflect.Kind, v
 		}
 		return protoreflect.ValueOfBytes(v), nil
 	case protoreflect.EnumKind:
+		enumDesc := desc.Enum()
+		if desc.IsMap() {
+			enumDesc = desc.MapValue().Enum()
+		}
+
 		if v, ok := value.(string); ok {
-			enum := desc.Enum().Values().ByName(protoreflect.Name(v))
+			enum := enumDesc.Values().ByName(protoreflect.Name(v))
 			if enum == nil {
 				return protoreflect.Value{}, fmt.Errorf("cannot found enum value by string %v", v)
 			}
@@ -268,14 +401,19 @@ func GoValueToProto(desc protoreflect.FieldDescriptor, kind protoreflect.Kind, v
 			return protoreflect.Value{}, err
 		}
 
-		if desc.Enum().Values().ByNumber(protoreflect.EnumNumber(v)) == nil {
+		if enumDesc.Values().ByNumber(protoreflect.EnumNumber(v)) == nil {
 			return protoreflect.Value{}, fmt.Errorf("cannot found enum value by number %v", v)
 		}
 
 		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(v)), nil
 	case protoreflect.MessageKind, protoreflect.GroupKind:
-		msg := dynamicpb.NewMessage(desc.Message())
-		if err := AnyToMessage(value, msg, inters...); err != nil {
+		msgDesc := desc.Message()
+		if desc.IsMap() {
+			msgDesc = desc.MapValue().Message()
+		}
+
+		msg := dynamicpb.NewMessage(msgDesc)
+		if err := e.anyToMessage(value, msg, path); err != nil {
 			return protoreflect.Value{}, err
 		}
 		return protoreflect.ValueOfMessage(msg), nil
@@ -283,3 +421,19 @@ func GoValueToProto(desc protoreflect.FieldDescriptor, kind protoreflect.Kind, v
 		return protoreflect.Value{}, fmt.Errorf("unsupported field type: %s", kind)
 	}
 }
+
+func isPopulated(message protoreflect.Message) bool {
+	populated := false
+	message.Range(func(protoreflect.FieldDescriptor, protoreflect.Value) bool {
+		populated = true
+		return false
+	})
+	return populated
+}
+
+func joinPath(path string, name protoreflect.Name) string {
+	if path == "" {
+		return string(name)
+	}
+	return path + "." + string(name)
+}
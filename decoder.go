@@ -2,24 +2,19 @@ package protomap
 
 import (
 	"google.golang.org/protobuf/proto"
-	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/dynamicpb"
 )
 
 func (d *Mapper) Decode(data []byte, messageName string, inters ...DecodeInterceptor) (any, error) {
-	desc, err := d.r.FindMessageByName(protoreflect.FullName(messageName))
+	desc, err := d.findMessage(messageName)
 	if err != nil {
 		return nil, err
 	}
 
-	if desc == nil {
-		return nil, ErrNoSuchMessage
-	}
-
-	message := dynamicpb.NewMessage(desc.Descriptor())
+	message := dynamicpb.NewMessage(desc)
 	if err := proto.Unmarshal(data, message); err != nil {
 		return nil, err
 	}
 
-	return MessageToAny(message, inters...)
+	return d.MessageToAny(message, inters...)
 }
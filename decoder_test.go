@@ -110,3 +110,22 @@ func TestDecoder_DecodeWithInters(t *testing.T) {
 		t.Fatal("expected and result are not equal")
 	}
 }
+
+func BenchmarkDecoder_Decode(b *testing.B) {
+	mapper, err := protomap.NewMapper(nil, testProto)
+	if err != nil {
+		b.Fatalf("decoder creation failed: %v", err)
+	}
+
+	binary, err := os.ReadFile(testBinary)
+	if err != nil {
+		b.Fatalf("binary data reading failed: %v", err)
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := mapper.Decode(binary, testMessage); err != nil {
+			b.Fatalf("binary data decoding failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+package protomap
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// DiffKind is a kind of Difference: value is added, removed or changed in the next payload.
+type DiffKind string
+
+const (
+	DiffAdded   DiffKind = "added"
+	DiffRemoved DiffKind = "removed"
+	DiffChanged DiffKind = "changed"
+)
+
+// Difference describes single field, list element or map entry, that differs in two payloads.
+// Path has the same syntax as in Validate; Old and New are decoded values, nil if absent.
+type Difference struct {
+	Path string
+	Kind DiffKind
+	Old  any
+	New  any
+}
+
+func (d Difference) String() string {
+	switch d.Kind {
+	case DiffAdded:
+		return fmt.Sprintf("%v: added %v", d.Path, d.New)
+	case DiffRemoved:
+		return fmt.Sprintf("%v: removed %v", d.Path, d.Old)
+	default:
+		return fmt.Sprintf("%v: changed from %v to %v", d.Path, d.Old, d.New)
+	}
+}
+
+// DiffOptions tunes comparison of payloads by Diff.
+type DiffOptions struct {
+	// FloatTolerance is a maximum absolute difference of float and double values considered equal
+	FloatTolerance float64
+	// IgnorePaths are skipped with all their nested fields, like "Inner" or "Inner.List[0]"
+	IgnorePaths []string
+}
+
+// Diff compares two payloads of message, each of them is a binary or map, that Encode accepts.
+// Fields with presence, list elements and map entries are reported as added or removed, if set only in one payload,
+// scalar fields without presence - as changed. Lists are compared by index, maps - by key, regardless of order.
+func (m *Mapper) Diff(messageName string, prev, next any, opts DiffOptions) ([]Difference, error) {
+	desc, err := m.findMessage(messageName)
+	if err != nil {
+		return nil, err
+	}
+
+	prevMessage, err := m.toMessage(desc, prev)
+	if err != nil {
+		return nil, fmt.Errorf("previous payload: %w", err)
+	}
+
+	nextMessage, err := m.toMessage(desc, next)
+	if err != nil {
+		return nil, fmt.Errorf("next payload: %w", err)
+	}
+
+	d := differ{opts: opts, dec: decoder{inters: m.DecodeInterceptors(), options: m.options}}
+	if m.registry != nil {
+		d.dec.fields = m.registry.fields
+	}
+
+	if err := d.diffMessages(prevMessage, nextMessage, "", ""); err != nil {
+		return nil, err
+	}
+	return d.diffs, nil
+}
+
+// toMessage unmarshals binary or converts map to message.
+func (m *Mapper) toMessage(desc protoreflect.MessageDescriptor, data any) (*dynamicpb.Message, error) {
+	message := dynamicpb.NewMessage(desc)
+
+	if b, ok := data.([]byte); ok {
+		return message, proto.Unmarshal(b, message)
+	}
+	return message, m.AnyToMessage(data, message)
+}
+
+type differ struct {
+	opts  DiffOptions
+	dec   decoder
+	diffs []Difference
+}
+
+func (d *differ) ignored(at string) bool {
+	for _, p := range d.opts.IgnorePaths {
+		if at == p || strings.HasPrefix(at, p+".") || strings.HasPrefix(at, p+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *differ) add(at string, kind DiffKind, prev, next any) {
+	d.diffs = append(d.diffs, Difference{Path: at, Kind: kind, Old: prev, New: next})
+}
+
+// diffMessages compares messages; path is used for field interceptors, at - for differences.
+func (d *differ) diffMessages(prev, next protoreflect.Message, path, at string) error {
+	fields := prev.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		fieldPath := joinPath(path, field.Name())
+		fieldAt := joinPath(at, field.Name())
+
+		if d.ignored(fieldAt) {
+			continue
+		}
+
+		prevHas, nextHas := prev.Has(field), next.Has(field)
+		if !prevHas && !nextHas {
+			continue
+		}
+
+		var err error
+		switch {
+		case field.IsList():
+			err = d.diffLists(field, prev.Get(field).List(), next.Get(field).List(), fieldPath, fieldAt)
+		case field.IsMap():
+			err = d.diffMaps(field, prev.Get(field).Map(), next.Get(field).Map(), fieldPath, fieldAt)
+		case field.HasPresence() && prevHas != nextHas:
+			err = d.diffPresence(field, field.Kind(), prev.Get(field), next.Get(field), prevHas, nextHas, fieldPath, fieldAt)
+		default:
+			err = d.diffValues(field, field.Kind(), prev.Get(field), next.Get(field), fieldPath, fieldAt)
+		}
+
+		if err != nil {
+			return fmt.Errorf("%v: %w", fieldAt, err)
+		}
+	}
+
+	return nil
+}
+
+func (d *differ) diffLists(field protoreflect.FieldDescriptor, prev, next protoreflect.List, path, at string) error {
+	for i := 0; i < max(prev.Len(), next.Len()); i++ {
+		elemAt := fmt.Sprintf("%v[%v]", at, i)
+		if d.ignored(elemAt) {
+			continue
+		}
+
+		var prevValue, nextValue protoreflect.Value
+		if i < prev.Len() {
+			prevValue = prev.Get(i)
+		}
+		if i < next.Len() {
+			nextValue = next.Get(i)
+		}
+
+		var err error
+		if i < prev.Len() && i < next.Len() {
+			err = d.diffValues(field, field.Kind(), prevValue, nextValue, path, elemAt)
+		} else {
+			err = d.diffPresence(field, field.Kind(), prevValue, nextValue, i < prev.Len(), i < next.Len(), path, elemAt)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *differ) diffMaps(field protoreflect.FieldDescriptor, prev, next protoreflect.Map, path, at string) error {
+	keys := make(map[string]protoreflect.MapKey)
+	for _, m := range []protoreflect.Map{prev, next} {
+		m.Range(func(mk protoreflect.MapKey, _ protoreflect.Value) bool {
+			keys[mk.String()] = mk
+			return true
+		})
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	kind := field.MapValue().Kind()
+	for _, k := range sorted {
+		elemAt := fmt.Sprintf("%v['%v']", at, k)
+		if d.ignored(elemAt) {
+			continue
+		}
+
+		mk := keys[k]
+		prevHas, nextHas := prev.Has(mk), next.Has(mk)
+
+		var err error
+		if prevHas && nextHas {
+			err = d.diffValues(field, kind, prev.Get(mk), next.Get(mk), path, elemAt)
+		} else {
+			err = d.diffPresence(field, kind, prev.Get(mk), next.Get(mk), prevHas, nextHas, path, elemAt)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// diffPresence reports value set only in one payload.
+func (d *differ) diffPresence(field protoreflect.FieldDescriptor, kind protoreflect.Kind, prev, next protoreflect.Value, prevHas, nextHas bool, path, at string) error {
+	if prevHas {
+		value, err := d.dec.protoToGoValue(field, kind, prev, path)
+		if err != nil {
+			return err
+		}
+		d.add(at, DiffRemoved, value, nil)
+		return nil
+	}
+
+	value, err := d.dec.protoToGoValue(field, kind, next, path)
+	if err != nil {
+		return err
+	}
+	d.add(at, DiffAdded, nil, value)
+	return nil
+}
+
+// diffValues compares values set in both payloads, recursively for messages.
+func (d *differ) diffValues(field protoreflect.FieldDescriptor, kind protoreflect.Kind, prev, next protoreflect.Value, path, at string) error {
+	if kind == protoreflect.MessageKind || kind == protoreflect.GroupKind {
+		return d.diffMessages(prev.Message(), next.Message(), path, at)
+	}
+
+	if d.equal(kind, prev, next) {
+		return nil
+	}
+
+	prevValue, err := d.dec.protoToGoValue(field, kind, prev, path)
+	if err != nil {
+		return err
+	}
+
+	nextValue, err := d.dec.protoToGoValue(field, kind, next, path)
+	if err != nil {
+		return err
+	}
+
+	d.add(at, DiffChanged, prevValue, nextValue)
+	return nil
+}
+
+func (d *differ) equal(kind protoreflect.Kind, prev, next protoreflect.Value) bool {
+	switch kind {
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		a, b := prev.Float(), next.Float()
+		if math.IsNaN(a) || math.IsNaN(b) {
+			return math.IsNaN(a) && math.IsNaN(b)
+		}
+		return a == b || math.Abs(a-b) <= d.opts.FloatTolerance
+	case protoreflect.BytesKind:
+		return bytes.Equal(prev.Bytes(), next.Bytes())
+	default:
+		return prev.Equal(next)
+	}
+}
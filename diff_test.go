@@ -0,0 +1,92 @@
+package protomap_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gekatateam/protomap"
+)
+
+func TestDiff_BinaryAndMap(t *testing.T) {
+	mapper, err := protomap.NewMapper(nil, testProto)
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	binary, err := os.ReadFile(testBinary)
+	if err != nil {
+		t.Fatalf("binary data reading failed: %v", err)
+	}
+
+	next := map[string]any{
+		"String": "test.key",
+		"Map":    map[string]any{"bar": "baz", "qux": "qux"},
+		"Binary": []byte("this is a test data"),
+		"List":   []any{"tag one"},
+		"Int":    -1337,
+		"Uint":   420,
+		"Float":  13.3700001,
+		"Inner":  map[string]any{"Foo": "fizzBuzz", "List": []any{0, 2, -1, 5}},
+		"IntMap": map[string]any{"13": 37},
+		"Type":   "type",
+		"Enum":   "OK",
+	}
+
+	diffs, err := mapper.Diff(testMessage, binary, next, protomap.DiffOptions{
+		FloatTolerance: 1e-6,
+		IgnorePaths:    []string{"Inner.List[3]"},
+	})
+	if err != nil {
+		t.Fatalf("diff failed: %v", err)
+	}
+
+	expected := []string{
+		"Map['bar']: changed from bar to baz",
+		"Map['foo']: removed foo",
+		"Map['qux']: added qux",
+		"List[1]: removed tag two",
+		"Inner.List[1]: changed from 1 to 2",
+		"Type: added type",
+		"Number: removed 1229",
+		"Enum: changed from FAILED to OK",
+	}
+
+	if len(diffs) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, diffs)
+	}
+
+	for i, d := range diffs {
+		if d.String() != expected[i] {
+			t.Fatalf("expected %q, got %q", expected[i], d.String())
+		}
+	}
+
+	diffs, err = mapper.Diff(testMessage, binary, binary, protomap.DiffOptions{})
+	if err != nil {
+		t.Fatalf("diff failed: %v", err)
+	}
+
+	if len(diffs) != 0 {
+		t.Fatalf("expected no differences, got %v", diffs)
+	}
+}
+
+func TestDiff_EnumMap(t *testing.T) {
+	mapper, err := protomap.NewMapper(nil, "./testdata/maps.proto")
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	diffs, err := mapper.Diff("protomap.test.WithEnumMap",
+		map[string]any{"States": map[string]any{"foo": "ACTIVE"}},
+		map[string]any{"States": map[string]any{"foo": "DISABLED"}},
+		protomap.DiffOptions{},
+	)
+	if err != nil {
+		t.Fatalf("diff failed: %v", err)
+	}
+
+	if len(diffs) != 1 || diffs[0].String() != "States['foo']: changed from ACTIVE to DISABLED" {
+		t.Fatalf("expected single enum change, got %v", diffs)
+	}
+}
@@ -0,0 +1,150 @@
+package dynamicgrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gekatateam/protomap"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+var (
+	ErrStreamingMethod = errors.New("method is streaming")
+	ErrUnaryMethod     = errors.New("method is unary")
+)
+
+// Client invokes RPCs described by Mapper descriptors, using maps as requests and responses.
+type Client struct {
+	mapper *protomap.Mapper
+	conn   grpc.ClientConnInterface
+
+	EncodeInterceptors []protomap.EncodeInterceptor
+	DecodeInterceptors []protomap.DecodeInterceptor
+}
+
+func NewClient(mapper *protomap.Mapper, conn grpc.ClientConnInterface) *Client {
+	return &Client{
+		mapper: mapper,
+		conn:   conn,
+	}
+}
+
+// Invoke calls unary method with request and returns decoded response.
+func (c *Client) Invoke(ctx context.Context, methodName string, request any, opts ...grpc.CallOption) (any, error) {
+	method, err := c.mapper.FindMethod(methodName)
+	if err != nil {
+		return nil, err
+	}
+
+	if method.IsStreamingClient() || method.IsStreamingServer() {
+		return nil, fmt.Errorf("%w: %v", ErrStreamingMethod, method.FullName())
+	}
+
+	in, err := c.encode(method.Input(), request)
+	if err != nil {
+		return nil, fmt.Errorf("request encoding failed: %w", err)
+	}
+
+	out := dynamicpb.NewMessage(method.Output())
+	if err := c.conn.Invoke(ctx, FullMethodName(method), in, out, opts...); err != nil {
+		return nil, err
+	}
+
+	return c.mapper.MessageToAny(out, c.DecodeInterceptors...)
+}
+
+// NewStream opens stream for client, server or bidirectional streaming method.
+func (c *Client) NewStream(ctx context.Context, methodName string, opts ...grpc.CallOption) (*ClientStream, error) {
+	method, err := c.mapper.FindMethod(methodName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !method.IsStreamingClient() && !method.IsStreamingServer() {
+		return nil, fmt.Errorf("%w: %v", ErrUnaryMethod, method.FullName())
+	}
+
+	desc := &grpc.StreamDesc{
+		StreamName:    string(method.Name()),
+		ServerStreams: method.IsStreamingServer(),
+		ClientStreams: method.IsStreamingClient(),
+	}
+
+	stream, err := c.conn.NewStream(ctx, desc, FullMethodName(method), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ClientStream{
+		ClientStream: stream,
+		client:       c,
+		method:       method,
+	}, nil
+}
+
+// ServerStream sends single request to server streaming method and closes send direction;
+// responses are read from returned stream.
+func (c *Client) ServerStream(ctx context.Context, methodName string, request any, opts ...grpc.CallOption) (*ClientStream, error) {
+	stream, err := c.NewStream(ctx, methodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stream.Send(request); err != nil {
+		return nil, err
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+
+	return stream, nil
+}
+
+func (c *Client) encode(desc protoreflect.MessageDescriptor, input any) (*dynamicpb.Message, error) {
+	message := dynamicpb.NewMessage(desc)
+	if err := c.mapper.AnyToMessage(input, message, c.EncodeInterceptors...); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// ClientStream wraps grpc.ClientStream, sending and receiving maps.
+type ClientStream struct {
+	grpc.ClientStream
+	client *Client
+	method protoreflect.MethodDescriptor
+}
+
+func (s *ClientStream) Send(request any) error {
+	in, err := s.client.encode(s.method.Input(), request)
+	if err != nil {
+		return fmt.Errorf("request encoding failed: %w", err)
+	}
+	return s.ClientStream.SendMsg(in)
+}
+
+// Recv reads next response; it returns io.EOF when stream ends successfully.
+func (s *ClientStream) Recv() (any, error) {
+	out := dynamicpb.NewMessage(s.method.Output())
+	if err := s.ClientStream.RecvMsg(out); err != nil {
+		return nil, err
+	}
+	return s.client.mapper.MessageToAny(out, s.client.DecodeInterceptors...)
+}
+
+// CloseAndRecv closes send direction and reads single response of client streaming method.
+func (s *ClientStream) CloseAndRecv() (any, error) {
+	if err := s.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return s.Recv()
+}
+
+// FullMethodName returns method name in "/pkg.Service/Method" form.
+func FullMethodName(method protoreflect.MethodDescriptor) string {
+	return fmt.Sprintf("/%v/%v", method.Parent().FullName(), method.Name())
+}
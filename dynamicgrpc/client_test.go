@@ -0,0 +1,299 @@
+package dynamicgrpc_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/gekatateam/protomap"
+	"github.com/gekatateam/protomap/dynamicgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const testServiceProto = "../testdata/service.proto"
+
+func dialTestServer(t *testing.T, register func(s *grpc.Server)) *grpc.ClientConn {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	register(server)
+
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("connection creation failed: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+// echoService is a hand-written Echo implementation built on dynamic messages,
+// so client tests do not depend on the dynamic server
+func echoService(t *testing.T, mapper *protomap.Mapper) *grpc.ServiceDesc {
+	service, err := mapper.Resolver().FindDescriptorByName("protomap.test.Echo")
+	if err != nil {
+		t.Fatalf("service lookup failed: %v", err)
+	}
+
+	request, err := mapper.Resolver().FindMessageByName("protomap.test.EchoRequest")
+	if err != nil {
+		t.Fatalf("request message lookup failed: %v", err)
+	}
+
+	response, err := mapper.Resolver().FindMessageByName("protomap.test.EchoResponse")
+	if err != nil {
+		t.Fatalf("response message lookup failed: %v", err)
+	}
+
+	reply := func(message string, index int64) (*dynamicpb.Message, error) {
+		out := dynamicpb.NewMessage(response.Descriptor())
+		err := protomap.AnyToMessage(map[string]any{"Message": message, "Index": index}, out)
+		return out, err
+	}
+
+	recv := func(stream grpc.ServerStream) (string, int64, error) {
+		in := dynamicpb.NewMessage(request.Descriptor())
+		if err := stream.RecvMsg(in); err != nil {
+			return "", 0, err
+		}
+
+		data, err := protomap.MessageToAny(in)
+		if err != nil {
+			return "", 0, err
+		}
+		return data.(map[string]any)["Message"].(string), data.(map[string]any)["Count"].(int64), nil
+	}
+
+	return &grpc.ServiceDesc{
+		ServiceName: string(service.FullName()),
+		HandlerType: (*any)(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "Unary",
+			Handler: func(_ any, _ context.Context, dec func(any) error, _ grpc.UnaryServerInterceptor) (any, error) {
+				in := dynamicpb.NewMessage(request.Descriptor())
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+
+				data, err := protomap.MessageToAny(in)
+				if err != nil {
+					return nil, err
+				}
+				return reply(data.(map[string]any)["Message"].(string), 0)
+			},
+		}},
+		Streams: []grpc.StreamDesc{
+			{
+				StreamName:    "ServerStream",
+				ServerStreams: true,
+				Handler: func(_ any, stream grpc.ServerStream) error {
+					message, count, err := recv(stream)
+					if err != nil {
+						return err
+					}
+
+					for i := int64(0); i < count; i++ {
+						out, err := reply(message, i)
+						if err != nil {
+							return err
+						}
+						if err := stream.SendMsg(out); err != nil {
+							return err
+						}
+					}
+					return nil
+				},
+			},
+			{
+				StreamName:    "ClientStream",
+				ClientStreams: true,
+				Handler: func(_ any, stream grpc.ServerStream) error {
+					var joined string
+					var count int64
+					for {
+						message, _, err := recv(stream)
+						if err == io.EOF {
+							break
+						}
+						if err != nil {
+							return err
+						}
+						joined += message
+						count++
+					}
+
+					out, err := reply(joined, count)
+					if err != nil {
+						return err
+					}
+					return stream.SendMsg(out)
+				},
+			},
+			{
+				StreamName:    "BidiStream",
+				ServerStreams: true,
+				ClientStreams: true,
+				Handler: func(_ any, stream grpc.ServerStream) error {
+					for i := int64(0); ; i++ {
+						message, _, err := recv(stream)
+						if err == io.EOF {
+							return nil
+						}
+						if err != nil {
+							return err
+						}
+
+						out, err := reply(message, i)
+						if err != nil {
+							return err
+						}
+						if err := stream.SendMsg(out); err != nil {
+							return err
+						}
+					}
+				},
+			},
+		},
+	}
+}
+
+func TestClient_Unary(t *testing.T) {
+	mapper, err := protomap.NewMapper(nil, testServiceProto)
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	conn := dialTestServer(t, func(s *grpc.Server) {
+		s.RegisterService(echoService(t, mapper), nil)
+	})
+	client := dynamicgrpc.NewClient(mapper, conn)
+
+	result, err := client.Invoke(context.Background(), "/protomap.test.Echo/Unary", map[string]any{"Message": "hello", "Count": 1})
+	if err != nil {
+		t.Fatalf("unary call failed: %v", err)
+	}
+
+	expected := map[string]any{"Message": "hello", "Index": int64(0)}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	if _, err := client.Invoke(context.Background(), "protomap.test.Echo.BidiStream", map[string]any{}); !errors.Is(err, dynamicgrpc.ErrStreamingMethod) {
+		t.Fatalf("expected streaming method error, got %v", err)
+	}
+
+	if _, err := client.Invoke(context.Background(), "protomap.test.Echo.Unknown", map[string]any{}); !errors.Is(err, protomap.ErrNoSuchMethod) {
+		t.Fatalf("expected no such method error, got %v", err)
+	}
+}
+
+func TestClient_Streams(t *testing.T) {
+	mapper, err := protomap.NewMapper(nil, testServiceProto)
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	conn := dialTestServer(t, func(s *grpc.Server) {
+		s.RegisterService(echoService(t, mapper), nil)
+	})
+	client := dynamicgrpc.NewClient(mapper, conn)
+	ctx := context.Background()
+
+	t.Run("server stream", func(t *testing.T) {
+		stream, err := client.ServerStream(ctx, "/protomap.test.Echo/ServerStream", map[string]any{"Message": "hi", "Count": 3})
+		if err != nil {
+			t.Fatalf("stream opening failed: %v", err)
+		}
+
+		for i := int64(0); ; i++ {
+			result, err := stream.Recv()
+			if err == io.EOF {
+				if i != 3 {
+					t.Fatalf("expected 3 responses, got %v", i)
+				}
+				break
+			}
+			if err != nil {
+				t.Fatalf("response receiving failed: %v", err)
+			}
+
+			expected := map[string]any{"Message": "hi", "Index": i}
+			if !reflect.DeepEqual(expected, result) {
+				t.Fatalf("expected %v, got %v", expected, result)
+			}
+		}
+	})
+
+	t.Run("client stream", func(t *testing.T) {
+		stream, err := client.NewStream(ctx, "/protomap.test.Echo/ClientStream")
+		if err != nil {
+			t.Fatalf("stream opening failed: %v", err)
+		}
+
+		for _, m := range []string{"a", "b", "c"} {
+			if err := stream.Send(map[string]any{"Message": m, "Count": 0}); err != nil {
+				t.Fatalf("request sending failed: %v", err)
+			}
+		}
+
+		result, err := stream.CloseAndRecv()
+		if err != nil {
+			t.Fatalf("response receiving failed: %v", err)
+		}
+
+		expected := map[string]any{"Message": "abc", "Index": int64(3)}
+		if !reflect.DeepEqual(expected, result) {
+			t.Fatalf("expected %v, got %v", expected, result)
+		}
+	})
+
+	t.Run("bidi stream", func(t *testing.T) {
+		stream, err := client.NewStream(ctx, "/protomap.test.Echo/BidiStream")
+		if err != nil {
+			t.Fatalf("stream opening failed: %v", err)
+		}
+
+		for i, m := range []string{"x", "y"} {
+			if err := stream.Send(map[string]any{"Message": m, "Count": 0}); err != nil {
+				t.Fatalf("request sending failed: %v", err)
+			}
+
+			result, err := stream.Recv()
+			if err != nil {
+				t.Fatalf("response receiving failed: %v", err)
+			}
+
+			expected := map[string]any{"Message": m, "Index": int64(i)}
+			if !reflect.DeepEqual(expected, result) {
+				t.Fatalf("expected %v, got %v", expected, result)
+			}
+		}
+
+		if err := stream.CloseSend(); err != nil {
+			t.Fatalf("stream closing failed: %v", err)
+		}
+
+		if _, err := stream.Recv(); err != io.EOF {
+			t.Fatalf("expected EOF, got %v", err)
+		}
+	})
+
+	t.Run("unary method", func(t *testing.T) {
+		if _, err := client.NewStream(ctx, "/protomap.test.Echo/Unary"); !errors.Is(err, dynamicgrpc.ErrUnaryMethod) {
+			t.Fatalf("expected unary method error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,204 @@
+package dynamicgrpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gekatateam/protomap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// ErrNoSuchService is the same error as protomap.ErrNoSuchService.
+var ErrNoSuchService = protomap.ErrNoSuchService
+
+type UnaryHandler func(ctx context.Context, request map[string]any) (map[string]any, error)
+
+// ServerStreamHandler handles server streaming method; every send call writes one response to client.
+type ServerStreamHandler func(ctx context.Context, request map[string]any, send func(response map[string]any) error) error
+
+// Service implements service described by Mapper descriptors with Go handlers.
+// Methods without handlers return Unimplemented status.
+type Service struct {
+	mapper  *protomap.Mapper
+	desc    protoreflect.ServiceDescriptor
+	unary   map[protoreflect.Name]UnaryHandler
+	streams map[protoreflect.Name]ServerStreamHandler
+
+	EncodeInterceptors []protomap.EncodeInterceptor
+	DecodeInterceptors []protomap.DecodeInterceptor
+}
+
+func NewService(mapper *protomap.Mapper, serviceName string) (*Service, error) {
+	service, err := mapper.FindService(serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Service{
+		mapper:  mapper,
+		desc:    service,
+		unary:   make(map[protoreflect.Name]UnaryHandler),
+		streams: make(map[protoreflect.Name]ServerStreamHandler),
+	}, nil
+}
+
+// HandleUnary sets handler for unary method; method name is a short name, like "Unary".
+func (s *Service) HandleUnary(methodName string, handler UnaryHandler) error {
+	method, err := s.method(methodName)
+	if err != nil {
+		return err
+	}
+
+	if method.IsStreamingClient() || method.IsStreamingServer() {
+		return fmt.Errorf("%w: %v", ErrStreamingMethod, method.FullName())
+	}
+
+	s.unary[method.Name()] = handler
+	return nil
+}
+
+// HandleServerStream sets handler for server streaming method.
+func (s *Service) HandleServerStream(methodName string, handler ServerStreamHandler) error {
+	method, err := s.method(methodName)
+	if err != nil {
+		return err
+	}
+
+	if method.IsStreamingClient() || !method.IsStreamingServer() {
+		return fmt.Errorf("method %v is not server streaming", method.FullName())
+	}
+
+	s.streams[method.Name()] = handler
+	return nil
+}
+
+// Register registers service in grpc server. Handlers must be set before registration.
+func (s *Service) Register(registrar grpc.ServiceRegistrar) {
+	registrar.RegisterService(s.ServiceDesc(), nil)
+}
+
+// ServiceDesc builds grpc.ServiceDesc from descriptor and handlers.
+func (s *Service) ServiceDesc() *grpc.ServiceDesc {
+	desc := &grpc.ServiceDesc{
+		ServiceName: string(s.desc.FullName()),
+		HandlerType: (*any)(nil),
+		Metadata:    s.desc.ParentFile().Path(),
+	}
+
+	methods := s.desc.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		method := methods.Get(i)
+
+		if !method.IsStreamingClient() && !method.IsStreamingServer() {
+			desc.Methods = append(desc.Methods, grpc.MethodDesc{
+				MethodName: string(method.Name()),
+				Handler:    s.unaryHandler(method),
+			})
+			continue
+		}
+
+		desc.Streams = append(desc.Streams, grpc.StreamDesc{
+			StreamName:    string(method.Name()),
+			ServerStreams: method.IsStreamingServer(),
+			ClientStreams: method.IsStreamingClient(),
+			Handler:       s.streamHandler(method),
+		})
+	}
+
+	return desc
+}
+
+func (s *Service) method(methodName string) (protoreflect.MethodDescriptor, error) {
+	method := s.desc.Methods().ByName(protoreflect.Name(methodName))
+	if method == nil {
+		return nil, fmt.Errorf("%w: %v.%v", protomap.ErrNoSuchMethod, s.desc.FullName(), methodName)
+	}
+	return method, nil
+}
+
+func (s *Service) unaryHandler(method protoreflect.MethodDescriptor) func(any, context.Context, func(any) error, grpc.UnaryServerInterceptor) (any, error) {
+	return func(_ any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+		in := dynamicpb.NewMessage(method.Input())
+		if err := dec(in); err != nil {
+			return nil, err
+		}
+
+		handle := func(ctx context.Context, req any) (any, error) {
+			handler, ok := s.unary[method.Name()]
+			if !ok {
+				return nil, status.Errorf(codes.Unimplemented, "method %v not implemented", method.Name())
+			}
+
+			request, err := s.decode(req.(*dynamicpb.Message))
+			if err != nil {
+				return nil, err
+			}
+
+			response, err := handler(ctx, request)
+			if err != nil {
+				return nil, err
+			}
+
+			return s.encode(method.Output(), response)
+		}
+
+		if interceptor == nil {
+			return handle(ctx, in)
+		}
+
+		return interceptor(ctx, in, &grpc.UnaryServerInfo{FullMethod: FullMethodName(method)}, handle)
+	}
+}
+
+func (s *Service) streamHandler(method protoreflect.MethodDescriptor) grpc.StreamHandler {
+	return func(_ any, stream grpc.ServerStream) error {
+		handler, ok := s.streams[method.Name()]
+		if !ok {
+			return status.Errorf(codes.Unimplemented, "method %v not implemented", method.Name())
+		}
+
+		in := dynamicpb.NewMessage(method.Input())
+		if err := stream.RecvMsg(in); err != nil {
+			return err
+		}
+
+		request, err := s.decode(in)
+		if err != nil {
+			return err
+		}
+
+		return handler(stream.Context(), request, func(response map[string]any) error {
+			out, err := s.encode(method.Output(), response)
+			if err != nil {
+				return err
+			}
+			return stream.SendMsg(out)
+		})
+	}
+}
+
+func (s *Service) decode(message *dynamicpb.Message) (map[string]any, error) {
+	result, err := s.mapper.MessageToAny(message, s.DecodeInterceptors...)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "request decoding failed: %v", err)
+	}
+
+	request, ok := result.(map[string]any)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "request decoded to %T, map[string]any expected", result)
+	}
+
+	return request, nil
+}
+
+func (s *Service) encode(desc protoreflect.MessageDescriptor, response map[string]any) (*dynamicpb.Message, error) {
+	out := dynamicpb.NewMessage(desc)
+	if err := s.mapper.AnyToMessage(response, out, s.EncodeInterceptors...); err != nil {
+		return nil, status.Errorf(codes.Internal, "response encoding failed: %v", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,132 @@
+package dynamicgrpc_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/gekatateam/protomap"
+	"github.com/gekatateam/protomap/dynamicgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestService_ServeWithClient(t *testing.T) {
+	mapper, err := protomap.NewMapper(nil, testServiceProto)
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	service, err := dynamicgrpc.NewService(mapper, "protomap.test.Echo")
+	if err != nil {
+		t.Fatalf("service creation failed: %v", err)
+	}
+
+	err = service.HandleUnary("Unary", func(_ context.Context, request map[string]any) (map[string]any, error) {
+		if request["Message"] == "" {
+			return nil, status.Error(codes.InvalidArgument, "empty message")
+		}
+		return map[string]any{"Message": request["Message"], "Index": request["Count"]}, nil
+	})
+	if err != nil {
+		t.Fatalf("unary handler setting failed: %v", err)
+	}
+
+	err = service.HandleServerStream("ServerStream", func(_ context.Context, request map[string]any, send func(map[string]any) error) error {
+		for i := int64(0); i < request["Count"].(int64); i++ {
+			if err := send(map[string]any{"Message": request["Message"], "Index": i}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("stream handler setting failed: %v", err)
+	}
+
+	conn := dialTestServer(t, func(s *grpc.Server) {
+		service.Register(s)
+	})
+	client := dynamicgrpc.NewClient(mapper, conn)
+	ctx := context.Background()
+
+	result, err := client.Invoke(ctx, "/protomap.test.Echo/Unary", map[string]any{"Message": "hello", "Count": 5})
+	if err != nil {
+		t.Fatalf("unary call failed: %v", err)
+	}
+
+	expected := map[string]any{"Message": "hello", "Index": int64(5)}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	_, err = client.Invoke(ctx, "/protomap.test.Echo/Unary", map[string]any{"Message": ""})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected invalid argument status, got %v", err)
+	}
+
+	stream, err := client.ServerStream(ctx, "/protomap.test.Echo/ServerStream", map[string]any{"Message": "hi", "Count": 2})
+	if err != nil {
+		t.Fatalf("stream opening failed: %v", err)
+	}
+
+	var responses []any
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("response receiving failed: %v", err)
+		}
+		responses = append(responses, response)
+	}
+
+	expectedResponses := []any{
+		map[string]any{"Message": "hi", "Index": int64(0)},
+		map[string]any{"Message": "hi", "Index": int64(1)},
+	}
+	if !reflect.DeepEqual(expectedResponses, responses) {
+		t.Fatalf("expected %v, got %v", expectedResponses, responses)
+	}
+
+	clientStream, err := client.NewStream(ctx, "/protomap.test.Echo/ClientStream")
+	if err != nil {
+		t.Fatalf("stream opening failed: %v", err)
+	}
+
+	if _, err := clientStream.CloseAndRecv(); status.Code(err) != codes.Unimplemented {
+		t.Fatalf("expected unimplemented status, got %v", err)
+	}
+}
+
+func TestService_Errors(t *testing.T) {
+	mapper, err := protomap.NewMapper(nil, testServiceProto)
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	if _, err := dynamicgrpc.NewService(mapper, "protomap.test.EchoRequest"); !errors.Is(err, protomap.ErrNoSuchService) {
+		t.Fatalf("expected no such service error, got %v", err)
+	}
+
+	service, err := dynamicgrpc.NewService(mapper, "protomap.test.Echo")
+	if err != nil {
+		t.Fatalf("service creation failed: %v", err)
+	}
+
+	if err := service.HandleUnary("Unknown", nil); !errors.Is(err, protomap.ErrNoSuchMethod) {
+		t.Fatalf("expected no such method error, got %v", err)
+	}
+
+	if err := service.HandleUnary("ServerStream", nil); !errors.Is(err, dynamicgrpc.ErrStreamingMethod) {
+		t.Fatalf("expected streaming method error, got %v", err)
+	}
+
+	if err := service.HandleServerStream("BidiStream", nil); err == nil {
+		t.Fatal("expected error for bidirectional method")
+	}
+}
@@ -2,24 +2,31 @@ package protomap
 
 import (
 	"google.golang.org/protobuf/proto"
-	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/dynamicpb"
 )
 
 func (e *Mapper) Encode(data any, messageName string, inters ...EncodeInterceptor) ([]byte, error) {
-	desc, err := e.r.FindMessageByName(protoreflect.FullName(messageName))
+	desc, err := e.findMessage(messageName)
 	if err != nil {
 		return nil, err
 	}
 
-	if desc == nil {
-		return nil, ErrNoSuchMessage
-	}
-
-	message := dynamicpb.NewMessage(desc.Descriptor())
-	if err := AnyToMessage(data, message, inters...); err != nil {
+	message := dynamicpb.NewMessage(desc)
+	if err := e.AnyToMessage(data, message, inters...); err != nil {
 		return nil, err
 	}
 
-	return proto.Marshal(message)
+	return e.marshal(message)
+}
+
+// WithDeterministic returns Mapper copy that produces canonical binaries: map entries are sorted by key,
+// so the same input always gives the same bytes with the same schema and protobuf library version.
+func (e *Mapper) WithDeterministic() *Mapper {
+	mapper := *e
+	mapper.deterministic = true
+	return &mapper
+}
+
+func (e *Mapper) marshal(message proto.Message) ([]byte, error) {
+	return proto.MarshalOptions{Deterministic: e.deterministic}.Marshal(message)
 }
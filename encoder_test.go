@@ -1,7 +1,9 @@
 package protomap_test
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"os"
 	"reflect"
 	"testing"
@@ -17,6 +19,7 @@ func TestEncoder_EncodeToBinaryThenDecode(t *testing.T) {
 	if err != nil {
 		t.Fatalf("decoder creation failed: %v", err)
 	}
+	mapper = mapper.WithDeterministic()
 
 	binary, err := os.ReadFile(testBinary)
 	if err != nil {
@@ -44,14 +47,12 @@ func TestEncoder_EncodeToBinaryThenDecode(t *testing.T) {
 		t.Fatalf("map input encoding failed: %v", err)
 	}
 
-	// we did this because fields order are not determined
-	// if !reflect.DeepEqual(binary, result) {
-	if len(binary) != len(result) {
+	if !bytes.Equal(binary, result) {
 		t.Log("------ expected -----")
 		t.Logf("%v", binary)
 		t.Log("------ result -----")
 		t.Logf("%v", result)
-		t.Fatal("expected and result length are not equal")
+		t.Fatal("expected and result are not equal")
 	}
 
 	expected := make(map[string]any)
@@ -92,6 +93,7 @@ func TestEncoder_EncodeWithIntersThenDecode(t *testing.T) {
 	if err != nil {
 		t.Fatalf("decoder creation failed: %v", err)
 	}
+	mapper = mapper.WithDeterministic()
 
 	binary, err := os.ReadFile(testIntersBinary)
 	if err != nil {
@@ -124,14 +126,12 @@ func TestEncoder_EncodeWithIntersThenDecode(t *testing.T) {
 		t.Fatalf("map input encoding failed: %v", err)
 	}
 
-	// we did this because fields order are not determined
-	// if !reflect.DeepEqual(binary, result) {
-	if len(binary) != len(result) {
+	if !bytes.Equal(binary, result) {
 		t.Log("------ expected -----")
 		t.Logf("%v", binary)
 		t.Log("------ result -----")
 		t.Logf("%v", result)
-		t.Fatal("expected and result length are not equal")
+		t.Fatal("expected and result are not equal")
 	}
 
 	decoderesult, err := mapper.Decode(binary, testIntersMessage, interceptors.DurationDecoder, interceptors.TimeDecoder)
@@ -151,3 +151,181 @@ func TestEncoder_EncodeWithIntersThenDecode(t *testing.T) {
 		t.Fatal("expected and result are not equal")
 	}
 }
+
+func TestEncoder_Deterministic(t *testing.T) {
+	mapper, err := protomap.NewMapper(nil, testProto)
+	if err != nil {
+		t.Fatalf("decoder creation failed: %v", err)
+	}
+	mapper = mapper.WithDeterministic()
+
+	tjson, err := os.ReadFile(testJson)
+	if err != nil {
+		t.Fatalf("json data reading failed: %v", err)
+	}
+
+	input := make(map[string]any)
+	err = json.Unmarshal(tjson, &input)
+	if err != nil {
+		t.Fatalf("json data unmarshaling failed: %v", err)
+	}
+
+	input, err = setInputKeysWithTypes(input)
+	if err != nil {
+		t.Fatalf("map input preparation failed: %v", err)
+	}
+
+	// many keys make random map iteration order visible in non-deterministic output
+	strMap, intMap := make(map[string]any), make(map[string]any)
+	for i := range 100 {
+		strMap[fmt.Sprintf("key%v", i)] = fmt.Sprintf("value%v", i)
+		intMap[fmt.Sprint(i)] = i
+	}
+	input["Map"], input["IntMap"] = strMap, intMap
+
+	expected, err := mapper.Encode(input, testMessage)
+	if err != nil {
+		t.Fatalf("map input encoding failed: %v", err)
+	}
+
+	for range 10 {
+		result, err := mapper.Encode(input, testMessage)
+		if err != nil {
+			t.Fatalf("map input encoding failed: %v", err)
+		}
+
+		if !bytes.Equal(expected, result) {
+			t.Fatalf("expected same bytes on every encoding, got %v and %v", expected, result)
+		}
+	}
+}
+
+func TestEncoder_MapWithMessageValues(t *testing.T) {
+	mapper, err := protomap.NewMapper(nil, "./testdata/maps.proto")
+	if err != nil {
+		t.Fatalf("decoder creation failed: %v", err)
+	}
+
+	input := map[string]any{
+		"Values": map[string]any{
+			"foo": map[string]any{"Name": "foo", "Count": int64(1)},
+			"bar": map[string]any{"Name": "bar", "Count": int64(2)},
+		},
+	}
+
+	binary, err := mapper.Encode(input, "protomap.test.WithMessageMap")
+	if err != nil {
+		t.Fatalf("map input encoding failed: %v", err)
+	}
+
+	result, err := mapper.Decode(binary, "protomap.test.WithMessageMap")
+	if err != nil {
+		t.Fatalf("binary data decoding failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(input, result) {
+		t.Fatalf("expected %v, got %v", input, result)
+	}
+}
+
+func TestEncoder_MapWithEnumValues(t *testing.T) {
+	mapper, err := protomap.NewMapper(nil, "./testdata/maps.proto")
+	if err != nil {
+		t.Fatalf("decoder creation failed: %v", err)
+	}
+
+	input := map[string]any{
+		"States": map[string]any{"foo": "ACTIVE", "bar": 2},
+	}
+
+	binary, err := mapper.Encode(input, "protomap.test.WithEnumMap")
+	if err != nil {
+		t.Fatalf("map input encoding failed: %v", err)
+	}
+
+	result, err := mapper.Decode(binary, "protomap.test.WithEnumMap")
+	if err != nil {
+		t.Fatalf("binary data decoding failed: %v", err)
+	}
+
+	expected := map[string]any{
+		"States": map[string]any{"foo": "ACTIVE", "bar": "DISABLED"},
+	}
+
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	if _, err := mapper.Encode(map[string]any{"States": map[string]any{"foo": "NOPE"}}, "protomap.test.WithEnumMap"); err == nil {
+		t.Fatal("expected unknown enum value error")
+	}
+}
+
+func BenchmarkEncoder_Encode(b *testing.B) {
+	mapper, err := protomap.NewMapper(nil, testProto)
+	if err != nil {
+		b.Fatalf("decoder creation failed: %v", err)
+	}
+
+	tjson, err := os.ReadFile(testJson)
+	if err != nil {
+		b.Fatalf("json data reading failed: %v", err)
+	}
+
+	input := make(map[string]any)
+	if err := json.Unmarshal(tjson, &input); err != nil {
+		b.Fatalf("json data unmarshaling failed: %v", err)
+	}
+
+	input, err = setInputKeysWithTypes(input)
+	if err != nil {
+		b.Fatalf("map input preparation failed: %v", err)
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := mapper.Encode(input, testMessage); err != nil {
+			b.Fatalf("map input encoding failed: %v", err)
+		}
+	}
+}
+
+func TestEncoder_DeterministicOneof(t *testing.T) {
+	mapper, err := protomap.NewMapper(nil, testProto)
+	if err != nil {
+		t.Fatalf("decoder creation failed: %v", err)
+	}
+	mapper = mapper.WithDeterministic()
+
+	input := func(oneOfKey string, oneOfValue any) map[string]any {
+		return map[string]any{
+			"String": "s",
+			"Int":    1,
+			oneOfKey: oneOfValue,
+			"Enum":   "FAILED",
+			"Map":    map[string]any{"foo": "bar", "bar": "baz"},
+			"IntMap": map[string]any{},
+			"List":   []any{},
+		}
+	}
+
+	expected, err := mapper.Encode(input("Type", "t"), testMessage)
+	if err != nil {
+		t.Fatalf("map input encoding failed: %v", err)
+	}
+
+	other, err := mapper.Encode(input("Number", 1.5), testMessage)
+	if err != nil {
+		t.Fatalf("map input encoding failed: %v", err)
+	}
+
+	// switching oneof member of binary gives the same bytes as encoding the same data
+	result, err := mapper.Set(testMessage, other, "Type", "t")
+	if err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+
+	if !bytes.Equal(expected, result.([]byte)) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+}
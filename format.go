@@ -0,0 +1,114 @@
+package protomap
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var unixUnits = map[string]time.Duration{
+	"unix":    time.Second,
+	"unix_ms": time.Millisecond,
+	"unix_us": time.Microsecond,
+	"unix_ns": time.Nanosecond,
+}
+
+// decodeFormat converts value to Go value according to (protomap.format) option.
+func decodeFormat(format string, kind protoreflect.Kind, value protoreflect.Value) (any, error) {
+	switch format {
+	case "uuid", "hex", "base64":
+		if kind != protoreflect.BytesKind {
+			return nil, fmt.Errorf("format %q is not applicable to %v field", format, kind)
+		}
+		return bytesToString(format, value.Bytes())
+	}
+
+	unit, ok := unixUnits[format]
+	if !ok {
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+
+	switch kind {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return unixToTime(value.Int(), unit), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return unixToTime(int64(value.Uint()), unit), nil
+	default:
+		return nil, fmt.Errorf("format %q is not applicable to %v field", format, kind)
+	}
+}
+
+// encodeFormat converts formatted input to []byte or int64 according to (protomap.format) option;
+// input of other types is not applied and left for regular conversion.
+func encodeFormat(format string, input any) (result any, applied bool, err error) {
+	switch format {
+	case "uuid", "hex", "base64":
+		s, ok := input.(string)
+		if !ok {
+			return nil, false, nil
+		}
+
+		b, err := stringToBytes(format, s)
+		return b, true, err
+	}
+
+	unit, ok := unixUnits[format]
+	if !ok {
+		return nil, true, fmt.Errorf("unknown format %q", format)
+	}
+
+	t, ok := input.(time.Time)
+	if !ok {
+		return nil, false, nil
+	}
+
+	perSecond := int64(time.Second / unit)
+	return t.Unix()*perSecond + int64(t.Nanosecond())/int64(unit), true, nil
+}
+
+func bytesToString(format string, b []byte) (string, error) {
+	switch format {
+	case "uuid":
+		// unset field is empty, like for other formats
+		if len(b) == 0 {
+			return "", nil
+		}
+		if len(b) != 16 {
+			return "", fmt.Errorf("uuid must be 16 bytes long, got %v", len(b))
+		}
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+	case "hex":
+		return hex.EncodeToString(b), nil
+	default:
+		return base64.StdEncoding.EncodeToString(b), nil
+	}
+}
+
+func stringToBytes(format string, s string) ([]byte, error) {
+	switch format {
+	case "uuid":
+		if s == "" {
+			return nil, nil
+		}
+		b, err := hex.DecodeString(strings.ReplaceAll(s, "-", ""))
+		if err != nil || len(b) != 16 {
+			return nil, fmt.Errorf("cannot parse %q as uuid", s)
+		}
+		return b, nil
+	case "hex":
+		return hex.DecodeString(s)
+	default:
+		return base64.StdEncoding.DecodeString(s)
+	}
+}
+
+func unixToTime(v int64, unit time.Duration) time.Time {
+	perSecond := int64(time.Second / unit)
+	return time.Unix(v/perSecond, (v%perSecond)*int64(unit)).UTC()
+}
@@ -0,0 +1,182 @@
+package grpcframe
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+const headerSize = 5
+
+// DefaultMaxMessageSize matches default gRPC receive message size limit.
+const DefaultMaxMessageSize = 4 << 20
+
+var (
+	ErrShortFrame      = errors.New("data is too short to contain grpc frame")
+	ErrMessageTooLarge = errors.New("grpc message is too large")
+	ErrInvalidFlag     = errors.New("invalid grpc frame compression flag")
+)
+
+// Frame is a gRPC length-prefixed message.
+// Payload is always stored uncompressed; Compressed flag shows how frame was or will be transmitted.
+type Frame struct {
+	Compressed bool
+	Payload    []byte
+}
+
+// ParseFrame parses single frame from data and returns it with unread rest of data.
+// Compressed payload is decompressed using gzip.
+func ParseFrame(data []byte, maxSize int) (Frame, []byte, error) {
+	if len(data) < headerSize {
+		return Frame{}, nil, ErrShortFrame
+	}
+
+	compressed, size, err := parseHeader(data[:headerSize], maxSize)
+	if err != nil {
+		return Frame{}, nil, err
+	}
+
+	if len(data)-headerSize < size {
+		return Frame{}, nil, ErrShortFrame
+	}
+
+	payload := data[headerSize : headerSize+size]
+	if compressed {
+		payload, err = decompress(payload, maxSize)
+		if err != nil {
+			return Frame{}, nil, err
+		}
+	}
+
+	return Frame{Compressed: compressed, Payload: payload}, data[headerSize+size:], nil
+}
+
+// ReadFrame reads single frame from r.
+// It returns io.EOF if r has no more data, and io.ErrUnexpectedEOF if frame is incomplete.
+func ReadFrame(r io.Reader, maxSize int) (Frame, error) {
+	header := make([]byte, headerSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return Frame{}, err
+	}
+
+	compressed, size, err := parseHeader(header, maxSize)
+	if err != nil {
+		return Frame{}, err
+	}
+
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return Frame{}, err
+	}
+
+	if compressed {
+		payload, err = decompress(payload, maxSize)
+		if err != nil {
+			return Frame{}, err
+		}
+	}
+
+	return Frame{Compressed: compressed, Payload: payload}, nil
+}
+
+// AppendFrame appends frame to dst, compressing payload with gzip if frame.Compressed is set.
+// Payload that does not fit into 32-bit length prefix is rejected with ErrMessageTooLarge.
+func AppendFrame(dst []byte, frame Frame) ([]byte, error) {
+	payload := frame.Payload
+	flag := byte(0)
+	if frame.Compressed {
+		var err error
+		payload, err = compress(payload)
+		if err != nil {
+			return nil, err
+		}
+		flag = 1
+	}
+
+	// frame length prefix is 32-bit
+	if uint64(len(payload)) > math.MaxUint32 {
+		return nil, fmt.Errorf("%w: %v > %v", ErrMessageTooLarge, len(payload), uint64(math.MaxUint32))
+	}
+
+	dst = append(dst, flag)
+	dst = binary.BigEndian.AppendUint32(dst, uint32(len(payload)))
+	return append(dst, payload...), nil
+}
+
+// WriteFrame writes frame to w.
+func WriteFrame(w io.Writer, frame Frame) error {
+	data, err := AppendFrame(make([]byte, 0, headerSize+len(frame.Payload)), frame)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(data)
+	return err
+}
+
+func parseHeader(header []byte, maxSize int) (bool, int, error) {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxMessageSize
+	}
+
+	var compressed bool
+	switch header[0] {
+	case 0:
+	case 1:
+		compressed = true
+	default:
+		return false, 0, fmt.Errorf("%w: %v", ErrInvalidFlag, header[0])
+	}
+
+	size := binary.BigEndian.Uint32(header[1:])
+	if uint64(size) > uint64(maxSize) {
+		return false, 0, fmt.Errorf("%w: %v > %v", ErrMessageTooLarge, size, maxSize)
+	}
+
+	return compressed, int(size), nil
+}
+
+func compress(payload []byte) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	w := gzip.NewWriter(buf)
+	if _, err := w.Write(payload); err != nil {
+		return nil, err
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func decompress(payload []byte, maxSize int) ([]byte, error) {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxMessageSize
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("gzip decompression failed: %w", err)
+	}
+	defer r.Close()
+
+	// read one byte more than allowed to detect too large messages
+	data, err := io.ReadAll(io.LimitReader(r, int64(maxSize)+1))
+	if err != nil {
+		return nil, fmt.Errorf("gzip decompression failed: %w", err)
+	}
+
+	if len(data) > maxSize {
+		return nil, fmt.Errorf("%w: decompressed size exceeds %v", ErrMessageTooLarge, maxSize)
+	}
+
+	return data, nil
+}
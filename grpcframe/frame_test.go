@@ -0,0 +1,131 @@
+package grpcframe_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/gekatateam/protomap"
+	"github.com/gekatateam/protomap/grpcframe"
+)
+
+const (
+	testServiceProto = "../testdata/service.proto"
+	testUnaryMethod  = "/protomap.test.Echo/Unary"
+)
+
+func TestFrame_EncodeThenDecode(t *testing.T) {
+	mapper, err := protomap.NewMapper(nil, testServiceProto)
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	for _, compressed := range []bool{false, true} {
+		request := map[string]any{"Message": "hello", "Count": int64(3)}
+		data, err := grpcframe.EncodeRequest(mapper, testUnaryMethod, request, compressed)
+		if err != nil {
+			t.Fatalf("request encoding failed: %v", err)
+		}
+
+		if (data[0] == 1) != compressed {
+			t.Fatalf("unexpected compression flag %v", data[0])
+		}
+
+		result, err := grpcframe.DecodeRequest(mapper, testUnaryMethod, data, 0)
+		if err != nil {
+			t.Fatalf("request decoding failed: %v", err)
+		}
+
+		if !reflect.DeepEqual(request, result) {
+			t.Fatalf("expected %v, got %v", request, result)
+		}
+
+		response := map[string]any{"Message": "hello", "Index": int64(1)}
+		data, err = grpcframe.EncodeResponse(mapper, "protomap.test.Echo.Unary", response, compressed)
+		if err != nil {
+			t.Fatalf("response encoding failed: %v", err)
+		}
+
+		result, err = grpcframe.DecodeResponse(mapper, "protomap.test.Echo.Unary", data, 0)
+		if err != nil {
+			t.Fatalf("response decoding failed: %v", err)
+		}
+
+		if !reflect.DeepEqual(response, result) {
+			t.Fatalf("expected %v, got %v", response, result)
+		}
+	}
+}
+
+func TestFrame_ReadWriteStream(t *testing.T) {
+	buf := &bytes.Buffer{}
+	frames := []grpcframe.Frame{
+		{Payload: []byte("first")},
+		{Compressed: true, Payload: []byte("second")},
+		{Payload: []byte{}},
+	}
+
+	for _, f := range frames {
+		if err := grpcframe.WriteFrame(buf, f); err != nil {
+			t.Fatalf("frame writing failed: %v", err)
+		}
+	}
+
+	for i, f := range frames {
+		read, err := grpcframe.ReadFrame(buf, 0)
+		if err != nil {
+			t.Fatalf("frame %v reading failed: %v", i, err)
+		}
+
+		if read.Compressed != f.Compressed || !bytes.Equal(read.Payload, f.Payload) {
+			t.Fatalf("frame %v: expected %+v, got %+v", i, f, read)
+		}
+	}
+
+	if _, err := grpcframe.ReadFrame(buf, 0); err != io.EOF {
+		t.Fatalf("expected EOF, got %v", err)
+	}
+}
+
+func TestFrame_Errors(t *testing.T) {
+	if _, _, err := grpcframe.ParseFrame([]byte{0, 0, 0}, 0); !errors.Is(err, grpcframe.ErrShortFrame) {
+		t.Fatalf("expected short frame error, got %v", err)
+	}
+
+	if _, _, err := grpcframe.ParseFrame([]byte{2, 0, 0, 0, 0}, 0); !errors.Is(err, grpcframe.ErrInvalidFlag) {
+		t.Fatalf("expected invalid flag error, got %v", err)
+	}
+
+	data, err := grpcframe.AppendFrame(nil, grpcframe.Frame{Payload: make([]byte, 16)})
+	if err != nil {
+		t.Fatalf("frame encoding failed: %v", err)
+	}
+
+	if _, _, err := grpcframe.ParseFrame(data, 8); !errors.Is(err, grpcframe.ErrMessageTooLarge) {
+		t.Fatalf("expected too large error, got %v", err)
+	}
+
+	compressed, err := grpcframe.AppendFrame(nil, grpcframe.Frame{Compressed: true, Payload: make([]byte, 1024)})
+	if err != nil {
+		t.Fatalf("frame encoding failed: %v", err)
+	}
+
+	if _, _, err := grpcframe.ParseFrame(compressed, 512); !errors.Is(err, grpcframe.ErrMessageTooLarge) {
+		t.Fatalf("expected too large error for decompressed payload, got %v", err)
+	}
+
+	mapper, err := protomap.NewMapper(nil, testServiceProto)
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	if _, err := grpcframe.DecodeRequest(mapper, "/protomap.test.Echo/Unknown", data, 0); !errors.Is(err, protomap.ErrNoSuchMethod) {
+		t.Fatalf("expected no such method error, got %v", err)
+	}
+
+	if _, err := grpcframe.DecodeRequest(mapper, testUnaryMethod, append(data, 0), 0); !errors.Is(err, grpcframe.ErrTrailingData) {
+		t.Fatalf("expected trailing data error, got %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package grpcframe
+
+import (
+	"errors"
+
+	"github.com/gekatateam/protomap"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var ErrTrailingData = errors.New("trailing data after grpc frame")
+
+// DecodeRequest decodes single frame as input type of method.
+func DecodeRequest(m *protomap.Mapper, methodName string, data []byte, maxSize int, inters ...protomap.DecodeInterceptor) (any, error) {
+	method, err := m.FindMethod(methodName)
+	if err != nil {
+		return nil, err
+	}
+	return decode(m, method.Input(), data, maxSize, inters...)
+}
+
+// DecodeResponse decodes single frame as output type of method.
+func DecodeResponse(m *protomap.Mapper, methodName string, data []byte, maxSize int, inters ...protomap.DecodeInterceptor) (any, error) {
+	method, err := m.FindMethod(methodName)
+	if err != nil {
+		return nil, err
+	}
+	return decode(m, method.Output(), data, maxSize, inters...)
+}
+
+// EncodeRequest encodes input as input type of method and wraps it into frame.
+func EncodeRequest(m *protomap.Mapper, methodName string, input any, compressed bool, inters ...protomap.EncodeInterceptor) ([]byte, error) {
+	method, err := m.FindMethod(methodName)
+	if err != nil {
+		return nil, err
+	}
+	return encode(m, method.Input(), input, compressed, inters...)
+}
+
+// EncodeResponse encodes input as output type of method and wraps it into frame.
+func EncodeResponse(m *protomap.Mapper, methodName string, input any, compressed bool, inters ...protomap.EncodeInterceptor) ([]byte, error) {
+	method, err := m.FindMethod(methodName)
+	if err != nil {
+		return nil, err
+	}
+	return encode(m, method.Output(), input, compressed, inters...)
+}
+
+func decode(m *protomap.Mapper, desc protoreflect.MessageDescriptor, data []byte, maxSize int, inters ...protomap.DecodeInterceptor) (any, error) {
+	frame, rest, err := ParseFrame(data, maxSize)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rest) > 0 {
+		return nil, ErrTrailingData
+	}
+
+	return m.Decode(frame.Payload, string(desc.FullName()), inters...)
+}
+
+func encode(m *protomap.Mapper, desc protoreflect.MessageDescriptor, input any, compressed bool, inters ...protomap.EncodeInterceptor) ([]byte, error) {
+	payload, err := m.Encode(input, string(desc.FullName()), inters...)
+	if err != nil {
+		return nil, err
+	}
+
+	return AppendFrame(nil, Frame{Compressed: compressed, Payload: payload})
+}
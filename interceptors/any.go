@@ -0,0 +1,139 @@
+package interceptors
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gekatateam/protomap"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const (
+	anyName       protoreflect.FullName = "google.protobuf.Any"
+	anyTypeKey                          = "@type"
+	anyValueKey                         = "value"
+	typeURLPrefix                       = "type.googleapis.com/"
+)
+
+// AnyDecoder returns interceptor that decodes google.protobuf.Any to map with "@type" key and embedded message fields.
+// Embedded message type is resolved by type URL through mapper, and embedded message is decoded by mapper
+// with inters and returned interceptor itself, so mapper registry interceptors, including field ones, are applied too.
+// If embedded message is decoded to non-map value, it is placed under "value" key.
+func AnyDecoder(mapper *protomap.Mapper, inters ...protomap.DecodeInterceptor) protomap.DecodeInterceptor {
+	all := append(make([]protomap.DecodeInterceptor, 0, len(inters)+1), inters...)
+	var decoder protomap.DecodeInterceptor
+	decoder = func(message protoreflect.Message) (result any, applied bool, err error) {
+		if message.Descriptor().FullName() != anyName {
+			return nil, false, nil
+		}
+
+		if !message.IsValid() {
+			return nil, true, nil
+		}
+
+		fields := message.Descriptor().Fields()
+		url := message.Get(fields.ByName("type_url")).String()
+		value := message.Get(fields.ByName("value")).Bytes()
+		if url == "" && len(value) == 0 {
+			return nil, true, nil
+		}
+
+		mt, err := mapper.Resolver().FindMessageByURL(url)
+		if err != nil {
+			return nil, true, fmt.Errorf("%w: %v", protomap.ErrNoSuchMessage, url)
+		}
+
+		embedded := dynamicpb.NewMessage(mt.Descriptor())
+		if err := proto.Unmarshal(value, embedded); err != nil {
+			return nil, true, fmt.Errorf("%v: %w", url, err)
+		}
+
+		decoded, err := mapper.MessageToAny(embedded, all...)
+		if err != nil {
+			return nil, true, fmt.Errorf("%v: %w", url, err)
+		}
+
+		data, ok := decoded.(map[string]any)
+		if !ok {
+			return map[string]any{anyTypeKey: url, anyValueKey: decoded}, true, nil
+		}
+
+		data[anyTypeKey] = url
+		return data, true, nil
+	}
+
+	all = append(all, decoder)
+	return decoder
+}
+
+// AnyEncoder returns interceptor that encodes map with "@type" key to google.protobuf.Any.
+// Type URL may be a full URL or just a message full name, in which case "type.googleapis.com/" prefix is added.
+// Embedded message is encoded by mapper with inters and returned interceptor itself.
+func AnyEncoder(mapper *protomap.Mapper, inters ...protomap.EncodeInterceptor) protomap.EncodeInterceptor {
+	all := append(make([]protomap.EncodeInterceptor, 0, len(inters)+1), inters...)
+	var encoder protomap.EncodeInterceptor
+	encoder = func(input any, message protoreflect.Message) (applied bool, err error) {
+		if message.Descriptor().FullName() != anyName {
+			return false, nil
+		}
+
+		if input == nil {
+			return true, nil
+		}
+
+		data, ok := input.(map[string]any)
+		if !ok {
+			return true, fmt.Errorf("cannot convert %T to %v", input, anyName)
+		}
+
+		url, ok := data[anyTypeKey].(string)
+		if !ok || url == "" {
+			return true, fmt.Errorf("%v key is missing or not a string", anyTypeKey)
+		}
+
+		if !strings.Contains(url, "/") {
+			url = typeURLPrefix + url
+		}
+
+		mt, err := mapper.Resolver().FindMessageByURL(url)
+		if err != nil {
+			return true, fmt.Errorf("%w: %v", protomap.ErrNoSuchMessage, url)
+		}
+
+		var embeddedData any = withoutKey(data, anyTypeKey)
+		if value, ok := data[anyValueKey]; ok && len(data) == 2 && mt.Descriptor().Fields().ByName(anyValueKey) == nil {
+			embeddedData = value
+		}
+
+		embedded := dynamicpb.NewMessage(mt.Descriptor())
+		if err := mapper.AnyToMessage(embeddedData, embedded, all...); err != nil {
+			return true, fmt.Errorf("%v: %w", url, err)
+		}
+
+		// embedded value is always canonical, so equal Any inputs give equal bytes
+		value, err := proto.MarshalOptions{Deterministic: true}.Marshal(embedded)
+		if err != nil {
+			return true, fmt.Errorf("%v: %w", url, err)
+		}
+
+		fields := message.Descriptor().Fields()
+		message.Set(fields.ByName("type_url"), protoreflect.ValueOfString(url))
+		message.Set(fields.ByName("value"), protoreflect.ValueOfBytes(value))
+		return true, nil
+	}
+
+	all = append(all, encoder)
+	return encoder
+}
+
+func withoutKey(data map[string]any, key string) map[string]any {
+	result := make(map[string]any, len(data))
+	for k, v := range data {
+		if k != key {
+			result[k] = v
+		}
+	}
+	return result
+}
@@ -1,31 +1,112 @@
 package interceptors
 
 import (
+	"errors"
+	"fmt"
 	"math"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/gekatateam/protomap"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
+var ErrDurationOverflow = errors.New("duration overflows time.Duration")
+
 func TimeDecoder(message protoreflect.Message) (result any, applied bool, err error) {
 	if message.Descriptor().FullName() != "google.protobuf.Timestamp" {
 		return nil, false, nil
 	}
 
-	seconds := message.Get(message.Descriptor().Fields().ByName("seconds")).Int()
-	nanos := message.Get(message.Descriptor().Fields().ByName("nanos")).Int()
-
+	seconds, nanos := secondsNanos(message)
 	return time.Unix(seconds, nanos).UTC(), true, nil
 }
 
+// TimeDecoderIn returns TimeDecoder variant that decodes google.protobuf.Timestamp to time.Time in given location.
+func TimeDecoderIn(loc *time.Location) protomap.DecodeInterceptor {
+	return func(message protoreflect.Message) (result any, applied bool, err error) {
+		if message.Descriptor().FullName() != "google.protobuf.Timestamp" {
+			return nil, false, nil
+		}
+
+		seconds, nanos := secondsNanos(message)
+		return time.Unix(seconds, nanos).In(loc), true, nil
+	}
+}
+
+// RFC3339TimeDecoder decodes google.protobuf.Timestamp to RFC 3339 string in UTC with nanoseconds, if any.
+func RFC3339TimeDecoder(message protoreflect.Message) (result any, applied bool, err error) {
+	if message.Descriptor().FullName() != "google.protobuf.Timestamp" {
+		return nil, false, nil
+	}
+
+	seconds, nanos := secondsNanos(message)
+	return time.Unix(seconds, nanos).UTC().Format(time.RFC3339Nano), true, nil
+}
+
+// UnixMillisTimeDecoder decodes google.protobuf.Timestamp to int64 Unix milliseconds.
+func UnixMillisTimeDecoder(message protoreflect.Message) (result any, applied bool, err error) {
+	if message.Descriptor().FullName() != "google.protobuf.Timestamp" {
+		return nil, false, nil
+	}
+
+	seconds, nanos := secondsNanos(message)
+	return time.Unix(seconds, nanos).UnixMilli(), true, nil
+}
+
+// DurationDecoder decodes google.protobuf.Duration to time.Duration,
+// clamping values out of time.Duration range.
 func DurationDecoder(message protoreflect.Message) (result any, applied bool, err error) {
 	if message.Descriptor().FullName() != "google.protobuf.Duration" {
 		return nil, false, nil
 	}
 
+	d, _ := toDuration(secondsNanos(message))
+	return d, true, nil
+}
+
+// StrictDurationDecoder decodes google.protobuf.Duration to time.Duration,
+// returning ErrDurationOverflow for values out of time.Duration range.
+func StrictDurationDecoder(message protoreflect.Message) (result any, applied bool, err error) {
+	if message.Descriptor().FullName() != "google.protobuf.Duration" {
+		return nil, false, nil
+	}
+
+	seconds, nanos := secondsNanos(message)
+	d, overflow := toDuration(seconds, nanos)
+	if overflow {
+		return nil, true, fmt.Errorf("%w: %v", ErrDurationOverflow, formatDuration(seconds, nanos))
+	}
+	return d, true, nil
+}
+
+// StringDurationDecoder decodes google.protobuf.Duration to protobuf JSON string, like "1.5s".
+func StringDurationDecoder(message protoreflect.Message) (result any, applied bool, err error) {
+	if message.Descriptor().FullName() != "google.protobuf.Duration" {
+		return nil, false, nil
+	}
+
+	return formatDuration(secondsNanos(message)), true, nil
+}
+
+// SecondsDurationDecoder decodes google.protobuf.Duration to float64 seconds.
+func SecondsDurationDecoder(message protoreflect.Message) (result any, applied bool, err error) {
+	if message.Descriptor().FullName() != "google.protobuf.Duration" {
+		return nil, false, nil
+	}
+
+	seconds, nanos := secondsNanos(message)
+	return float64(seconds) + float64(nanos)/1e9, true, nil
+}
+
+func secondsNanos(message protoreflect.Message) (int64, int64) {
 	seconds := message.Get(message.Descriptor().Fields().ByName("seconds")).Int()
 	nanos := message.Get(message.Descriptor().Fields().ByName("nanos")).Int()
+	return seconds, nanos
+}
 
+func toDuration(seconds, nanos int64) (time.Duration, bool) {
 	d := time.Duration(seconds) * time.Second
 	overflow := d/time.Second != time.Duration(seconds)
 	d += time.Duration(nanos) * time.Nanosecond
@@ -34,10 +115,30 @@ func DurationDecoder(message protoreflect.Message) (result any, applied bool, er
 	if overflow {
 		switch {
 		case seconds < 0:
-			return time.Duration(math.MinInt64), true, nil
+			return time.Duration(math.MinInt64), true
 		case seconds > 0:
-			return time.Duration(math.MaxInt64), true, nil
+			return time.Duration(math.MaxInt64), true
 		}
 	}
-	return d, true, nil
+	return d, false
+}
+
+// formatDuration formats duration as protobuf JSON does, with 0, 3, 6 or 9 fractional digits
+func formatDuration(seconds, nanos int64) string {
+	sign := ""
+	if seconds < 0 || nanos < 0 {
+		sign = "-"
+		seconds, nanos = -seconds, -nanos
+	}
+
+	result := sign + strconv.FormatInt(seconds, 10)
+	if nanos != 0 {
+		fraction := fmt.Sprintf("%09d", nanos)
+		for strings.HasSuffix(fraction, "000") {
+			fraction = strings.TrimSuffix(fraction, "000")
+		}
+		result += "." + fraction
+	}
+
+	return result + "s"
 }
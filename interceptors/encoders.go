@@ -1,43 +1,219 @@
 package interceptors
 
 import (
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/gekatateam/protomap"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
+const (
+	// 0001-01-01T00:00:00Z and 9999-12-31T23:59:59Z
+	minTimestampSeconds = -62135596800
+	maxTimestampSeconds = 253402300799
+
+	// ~10000 years, as defined in google/protobuf/duration.proto
+	maxDurationSeconds = 315576000000
+)
+
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+var protoDurationRe = regexp.MustCompile(`^(-)?(\d+)(?:\.(\d{1,9}))?s$`)
+
+// TimeEncoder encodes time.Time and RFC 3339/ISO 8601 strings to google.protobuf.Timestamp.
+// Strings without zone offset are treated as UTC.
 func TimeEncoder(input any, message protoreflect.Message) (applied bool, err error) {
+	return encodeTime(input, message, 0)
+}
+
+// UnixTimeEncoder returns TimeEncoder variant that also encodes numbers as Unix time in given unit,
+// e.g. time.Second or time.Millisecond.
+func UnixTimeEncoder(unit time.Duration) protomap.EncodeInterceptor {
+	return func(input any, message protoreflect.Message) (applied bool, err error) {
+		return encodeTime(input, message, unit)
+	}
+}
+
+// DurationEncoder encodes time.Duration, protobuf JSON duration strings, like "1.5s",
+// and Go duration strings, like "1h30m", to google.protobuf.Duration.
+func DurationEncoder(input any, message protoreflect.Message) (applied bool, err error) {
+	return encodeDuration(input, message, 0)
+}
+
+// NumericDurationEncoder returns DurationEncoder variant that also encodes numbers as amount of given unit,
+// e.g. time.Second or time.Millisecond.
+func NumericDurationEncoder(unit time.Duration) protomap.EncodeInterceptor {
+	return func(input any, message protoreflect.Message) (applied bool, err error) {
+		return encodeDuration(input, message, unit)
+	}
+}
+
+func encodeTime(input any, message protoreflect.Message, unit time.Duration) (applied bool, err error) {
 	if message.Descriptor().FullName() != "google.protobuf.Timestamp" {
 		return false, nil
 	}
 
-	t, ok := input.(time.Time)
-	if !ok {
-		return false, nil
+	var secs, nanos int64
+	switch t := input.(type) {
+	case time.Time:
+		secs, nanos = t.Unix(), int64(t.Nanosecond())
+	case string:
+		parsed, err := parseTime(t)
+		if err != nil {
+			return true, err
+		}
+		secs, nanos = parsed.Unix(), int64(parsed.Nanosecond())
+	default:
+		if unit <= 0 || !isNumber(input) {
+			return false, nil
+		}
+
+		secs, nanos, err = numberToSecondsNanos(input, unit)
+		if err != nil {
+			return true, err
+		}
 	}
 
-	message.Set(message.Descriptor().Fields().ByName("seconds"), protoreflect.ValueOfInt64(t.Unix()))
-	message.Set(message.Descriptor().Fields().ByName("nanos"), protoreflect.ValueOfInt32(int32(t.Nanosecond())))
+	// timestamp nanos are always non-negative
+	if nanos < 0 {
+		secs, nanos = secs-1, nanos+1e9
+	}
+	if nanos >= 1e9 {
+		secs, nanos = secs+1, nanos-1e9
+	}
+
+	if secs < minTimestampSeconds || secs > maxTimestampSeconds {
+		return true, fmt.Errorf("timestamp is out of range [0001-01-01T00:00:00Z, 9999-12-31T23:59:59Z]")
+	}
+
+	message.Set(message.Descriptor().Fields().ByName("seconds"), protoreflect.ValueOfInt64(secs))
+	message.Set(message.Descriptor().Fields().ByName("nanos"), protoreflect.ValueOfInt32(int32(nanos)))
 
 	return true, nil
 }
 
-func DurationEncoder(input any, message protoreflect.Message) (applied bool, err error) {
+func encodeDuration(input any, message protoreflect.Message, unit time.Duration) (applied bool, err error) {
 	if message.Descriptor().FullName() != "google.protobuf.Duration" {
 		return false, nil
 	}
 
-	d, ok := input.(time.Duration)
-	if !ok {
-		return false, nil
+	var secs, nanos int64
+	switch t := input.(type) {
+	case time.Duration:
+		nanos = t.Nanoseconds()
+		secs = nanos / 1e9
+		nanos -= secs * 1e9
+	case string:
+		secs, nanos, err = parseDuration(t)
+		if err != nil {
+			return true, err
+		}
+	default:
+		if unit <= 0 || !isNumber(input) {
+			return false, nil
+		}
+
+		secs, nanos, err = numberToSecondsNanos(input, unit)
+		if err != nil {
+			return true, err
+		}
 	}
 
-	nanos := d.Nanoseconds()
-	secs := nanos / 1e9
-	nanos -= secs * 1e9
+	// duration nanos have the same sign as seconds
+	secs, nanos = secs+nanos/1e9, nanos%1e9
+
+	if secs < -maxDurationSeconds || secs > maxDurationSeconds {
+		return true, fmt.Errorf("duration is out of range [-%vs, %vs]", maxDurationSeconds, maxDurationSeconds)
+	}
 
 	message.Set(message.Descriptor().Fields().ByName("seconds"), protoreflect.ValueOfInt64(secs))
 	message.Set(message.Descriptor().Fields().ByName("nanos"), protoreflect.ValueOfInt32(int32(nanos)))
 
 	return true, nil
 }
+
+func parseTime(s string) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q as RFC 3339/ISO 8601 time", s)
+}
+
+// parseDuration parses protobuf JSON duration format first, because it allows
+// larger values than time.Duration, and then falls back to Go duration format
+func parseDuration(s string) (secs int64, nanos int64, err error) {
+	if match := protoDurationRe.FindStringSubmatch(s); match != nil {
+		secs, err = strconv.ParseInt(match[2], 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("cannot parse %q as duration: %w", s, err)
+		}
+
+		if match[3] != "" {
+			nanos, _ = strconv.ParseInt(match[3]+strings.Repeat("0", 9-len(match[3])), 10, 64)
+		}
+
+		if match[1] == "-" {
+			secs, nanos = -secs, -nanos
+		}
+		return secs, nanos, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, 0, fmt.Errorf("cannot parse %q as duration: %w", s, err)
+	}
+
+	nanos = d.Nanoseconds()
+	secs = nanos / 1e9
+	return secs, nanos - secs*1e9, nil
+}
+
+func numberToSecondsNanos(input any, unit time.Duration) (secs int64, nanos int64, err error) {
+	switch t := input.(type) {
+	case float32, float64:
+		f, _ := protomap.AnyToFloat(t)
+		total := f * float64(unit) / float64(time.Second)
+		if math.IsNaN(total) || math.Abs(total) > math.MaxInt64 {
+			return 0, 0, fmt.Errorf("%v is out of range", f)
+		}
+
+		whole := math.Trunc(total)
+		return int64(whole), int64(math.Round((total - whole) * 1e9)), nil
+	default:
+		v, err := protomap.AnyToInteger(t)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		// unit may be not a whole number of seconds or an exact fraction of one, like 1500ms,
+		// so value is converted to nanoseconds first; both parts are truncated towards zero and have the same sign
+		total := new(big.Int).Mul(big.NewInt(v), big.NewInt(int64(unit)))
+		whole, fraction := new(big.Int).QuoRem(total, big.NewInt(int64(time.Second)), new(big.Int))
+		if !whole.IsInt64() {
+			return 0, 0, fmt.Errorf("%v is out of range", v)
+		}
+		return whole.Int64(), fraction.Int64(), nil
+	}
+}
+
+func isNumber(input any) bool {
+	switch input.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return true
+	default:
+		return false
+	}
+}
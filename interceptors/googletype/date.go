@@ -0,0 +1,182 @@
+package googletype
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	dateName      protoreflect.FullName = "google.type.Date"
+	timeOfDayName protoreflect.FullName = "google.type.TimeOfDay"
+)
+
+// Date is a civil date; zero Year, Month or Day means that part is not specified,
+// like in google.type.Date.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, int(d.Month), d.Day)
+}
+
+// Time returns midnight of date in given location.
+func (d Date) Time(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// TimeOfDay is a time of day without date and time zone, like google.type.TimeOfDay.
+type TimeOfDay struct {
+	Hours   int
+	Minutes int
+	Seconds int
+	Nanos   int
+}
+
+func (t TimeOfDay) String() string {
+	if t.Nanos != 0 {
+		return fmt.Sprintf("%02d:%02d:%02d.%09d", t.Hours, t.Minutes, t.Seconds, t.Nanos)
+	}
+	return fmt.Sprintf("%02d:%02d:%02d", t.Hours, t.Minutes, t.Seconds)
+}
+
+// DateDecoder decodes google.type.Date to Date, or nil if field is absent.
+func DateDecoder(message protoreflect.Message) (result any, applied bool, err error) {
+	if message.Descriptor().FullName() != dateName {
+		return nil, false, nil
+	}
+
+	if !message.IsValid() {
+		return nil, true, nil
+	}
+
+	return messageToDate(message), true, nil
+}
+
+// DateTimeDecoder decodes google.type.Date to time.Time at midnight UTC, or nil if field is absent.
+// Dates with unspecified parts cannot be represented as time.Time, so they are rejected.
+func DateTimeDecoder(message protoreflect.Message) (result any, applied bool, err error) {
+	if message.Descriptor().FullName() != dateName {
+		return nil, false, nil
+	}
+
+	if !message.IsValid() {
+		return nil, true, nil
+	}
+
+	d := messageToDate(message)
+	if d.Year == 0 || d.Month == 0 || d.Day == 0 {
+		return nil, true, fmt.Errorf("date %v is partial and cannot be converted to time.Time", d)
+	}
+
+	return d.Time(time.UTC), true, nil
+}
+
+// DateEncoder encodes Date, time.Time (date part only) and "2006-01-02" strings to google.type.Date.
+func DateEncoder(input any, message protoreflect.Message) (applied bool, err error) {
+	if message.Descriptor().FullName() != dateName {
+		return false, nil
+	}
+
+	var d Date
+	switch t := input.(type) {
+	case nil:
+		return true, nil
+	case Date:
+		d = t
+	case time.Time:
+		d = Date{Year: t.Year(), Month: t.Month(), Day: t.Day()}
+	case string:
+		parsed, err := time.Parse(time.DateOnly, t)
+		if err != nil {
+			return true, fmt.Errorf("cannot parse %q as date: %w", t, err)
+		}
+		d = Date{Year: parsed.Year(), Month: parsed.Month(), Day: parsed.Day()}
+	default:
+		return false, nil
+	}
+
+	if d.Year < 0 || d.Year > 9999 || d.Month < 0 || d.Month > 12 || d.Day < 0 || d.Day > 31 {
+		return true, fmt.Errorf("date %v is out of range", d)
+	}
+
+	fields := message.Descriptor().Fields()
+	message.Set(fields.ByName("year"), protoreflect.ValueOfInt32(int32(d.Year)))
+	message.Set(fields.ByName("month"), protoreflect.ValueOfInt32(int32(d.Month)))
+	message.Set(fields.ByName("day"), protoreflect.ValueOfInt32(int32(d.Day)))
+	return true, nil
+}
+
+// TimeOfDayDecoder decodes google.type.TimeOfDay to TimeOfDay, or nil if field is absent.
+func TimeOfDayDecoder(message protoreflect.Message) (result any, applied bool, err error) {
+	if message.Descriptor().FullName() != timeOfDayName {
+		return nil, false, nil
+	}
+
+	if !message.IsValid() {
+		return nil, true, nil
+	}
+
+	fields := message.Descriptor().Fields()
+	return TimeOfDay{
+		Hours:   int(message.Get(fields.ByName("hours")).Int()),
+		Minutes: int(message.Get(fields.ByName("minutes")).Int()),
+		Seconds: int(message.Get(fields.ByName("seconds")).Int()),
+		Nanos:   int(message.Get(fields.ByName("nanos")).Int()),
+	}, true, nil
+}
+
+// TimeOfDayEncoder encodes TimeOfDay, time.Time (clock part only) and "15:04:05.999999999" or "15:04" strings
+// to google.type.TimeOfDay.
+func TimeOfDayEncoder(input any, message protoreflect.Message) (applied bool, err error) {
+	if message.Descriptor().FullName() != timeOfDayName {
+		return false, nil
+	}
+
+	var t TimeOfDay
+	switch v := input.(type) {
+	case nil:
+		return true, nil
+	case TimeOfDay:
+		t = v
+	case time.Time:
+		t = TimeOfDay{Hours: v.Hour(), Minutes: v.Minute(), Seconds: v.Second(), Nanos: v.Nanosecond()}
+	case string:
+		parsed, err := time.Parse("15:04:05.999999999", v)
+		if err != nil {
+			parsed, err = time.Parse("15:04", v)
+		}
+		if err != nil {
+			return true, fmt.Errorf("cannot parse %q as time of day", v)
+		}
+		t = TimeOfDay{Hours: parsed.Hour(), Minutes: parsed.Minute(), Seconds: parsed.Second(), Nanos: parsed.Nanosecond()}
+	default:
+		return false, nil
+	}
+
+	// 24:00:00 is allowed for scenarios like business closing time, 60 seconds is allowed for leap seconds
+	if t.Hours < 0 || t.Hours > 24 || t.Minutes < 0 || t.Minutes > 59 || t.Seconds < 0 || t.Seconds > 60 ||
+		t.Nanos < 0 || t.Nanos > 999999999 || (t.Hours == 24 && (t.Minutes != 0 || t.Seconds != 0 || t.Nanos != 0)) {
+		return true, fmt.Errorf("time of day %v is out of range", t)
+	}
+
+	fields := message.Descriptor().Fields()
+	message.Set(fields.ByName("hours"), protoreflect.ValueOfInt32(int32(t.Hours)))
+	message.Set(fields.ByName("minutes"), protoreflect.ValueOfInt32(int32(t.Minutes)))
+	message.Set(fields.ByName("seconds"), protoreflect.ValueOfInt32(int32(t.Seconds)))
+	message.Set(fields.ByName("nanos"), protoreflect.ValueOfInt32(int32(t.Nanos)))
+	return true, nil
+}
+
+func messageToDate(message protoreflect.Message) Date {
+	fields := message.Descriptor().Fields()
+	return Date{
+		Year:  int(message.Get(fields.ByName("year")).Int()),
+		Month: time.Month(message.Get(fields.ByName("month")).Int()),
+		Day:   int(message.Get(fields.ByName("day")).Int()),
+	}
+}
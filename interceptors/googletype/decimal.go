@@ -0,0 +1,93 @@
+package googletype
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+
+	"github.com/gekatateam/protomap"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	decimalName protoreflect.FullName = "google.type.Decimal"
+
+	// precision of big.Float decoded from google.type.Decimal, in bits
+	decimalPrecision = 256
+)
+
+var decimalRe = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)([eE][+-]?\d+)?$`)
+
+// DecimalDecoder decodes google.type.Decimal to its string value, or nil if field is absent.
+func DecimalDecoder(message protoreflect.Message) (result any, applied bool, err error) {
+	if message.Descriptor().FullName() != decimalName {
+		return nil, false, nil
+	}
+
+	if !message.IsValid() {
+		return nil, true, nil
+	}
+
+	return message.Get(message.Descriptor().Fields().ByName("value")).String(), true, nil
+}
+
+// BigFloatDecimalDecoder decodes google.type.Decimal to *big.Float, or nil if field is absent.
+func BigFloatDecimalDecoder(message protoreflect.Message) (result any, applied bool, err error) {
+	if message.Descriptor().FullName() != decimalName {
+		return nil, false, nil
+	}
+
+	if !message.IsValid() {
+		return nil, true, nil
+	}
+
+	value := message.Get(message.Descriptor().Fields().ByName("value")).String()
+	f, _, err := big.ParseFloat(value, 10, decimalPrecision, big.ToNearestEven)
+	if err != nil {
+		return nil, true, fmt.Errorf("cannot parse %q as decimal: %w", value, err)
+	}
+
+	return f, true, nil
+}
+
+// DecimalEncoder encodes decimal strings, *big.Float and numbers to google.type.Decimal.
+func DecimalEncoder(input any, message protoreflect.Message) (applied bool, err error) {
+	if message.Descriptor().FullName() != decimalName {
+		return false, nil
+	}
+
+	var value string
+	switch t := input.(type) {
+	case nil:
+		return true, nil
+	case string:
+		if !decimalRe.MatchString(t) {
+			return true, fmt.Errorf("%q is not a decimal", t)
+		}
+		value = t
+	case *big.Float:
+		if t == nil {
+			return true, fmt.Errorf("nil *big.Float cannot be converted to %v", decimalName)
+		}
+		if t.IsInf() {
+			return true, fmt.Errorf("infinity cannot be converted to %v", decimalName)
+		}
+		value = t.Text('g', -1)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		f, _ := protomap.AnyToFloat(t)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return true, fmt.Errorf("%v cannot be converted to %v", f, decimalName)
+		}
+
+		value, err = protomap.AnyToString(t)
+		if err != nil {
+			return true, err
+		}
+	default:
+		return false, nil
+	}
+
+	message.Set(message.Descriptor().Fields().ByName("value"), protoreflect.ValueOfString(value))
+	return true, nil
+}
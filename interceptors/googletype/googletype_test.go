@@ -0,0 +1,154 @@
+package googletype_test
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/gekatateam/protomap"
+	"github.com/gekatateam/protomap/interceptors/googletype"
+)
+
+const testMessage = "protomap.test.WithGoogleTypes"
+
+func newTestMapper(t *testing.T) *protomap.Mapper {
+	compiler := protocompile.Compiler{
+		Resolver: &protocompile.SourceResolver{ImportPaths: []string{"../../testdata"}},
+	}
+
+	mapper, err := protomap.NewMapper(&compiler, "googletype.proto")
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	registry := protomap.NewRegistry()
+	googletype.Register(registry)
+	return mapper.WithRegistry(registry)
+}
+
+func TestGoogleType_EncodeThenDecode(t *testing.T) {
+	mapper := newTestMapper(t)
+
+	input := map[string]any{
+		"Date":     "2024-02-29",
+		"Time":     "18:30:15.5",
+		"Price":    googletype.Money{CurrencyCode: "USD", Amount: big.NewRat(-1225, 100)},
+		"Location": googletype.LatLng{Latitude: 55.75, Longitude: 37.61},
+		"Rate":     "1.25e-3",
+		"NoDate":   nil,
+	}
+
+	binary, err := mapper.Encode(input, testMessage)
+	if err != nil {
+		t.Fatalf("map input encoding failed: %v", err)
+	}
+
+	result, err := mapper.Decode(binary, testMessage)
+	if err != nil {
+		t.Fatalf("binary data decoding failed: %v", err)
+	}
+
+	expected := map[string]any{
+		"Date":     googletype.Date{Year: 2024, Month: time.February, Day: 29},
+		"Time":     googletype.TimeOfDay{Hours: 18, Minutes: 30, Seconds: 15, Nanos: 5e8},
+		"Price":    "-12.25 USD",
+		"Location": googletype.LatLng{Latitude: 55.75, Longitude: 37.61},
+		"Rate":     "1.25e-3",
+		"NoDate":   nil,
+	}
+
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	result, err = mapper.Decode(binary, testMessage, googletype.DateTimeDecoder, googletype.BigFloatDecimalDecoder, googletype.StructMoneyDecoder)
+	if err != nil {
+		t.Fatalf("binary data decoding failed: %v", err)
+	}
+
+	data := result.(map[string]any)
+	if !data["Date"].(time.Time).Equal(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected date as time: %v", data["Date"])
+	}
+
+	if f, _ := data["Rate"].(*big.Float).Float64(); f != 0.00125 {
+		t.Fatalf("unexpected decimal as big.Float: %v", data["Rate"])
+	}
+
+	if price := (googletype.Money{CurrencyCode: "USD", Amount: big.NewRat(-1225, 100)}); !reflect.DeepEqual(price, data["Price"]) {
+		t.Fatalf("expected money %v, got %v", price, data["Price"])
+	}
+}
+
+func TestGoogleType_MoneyForms(t *testing.T) {
+	mapper := newTestMapper(t)
+
+	for input, expected := range map[any]string{
+		"12.34":          "12.34",
+		"-0.5 EUR":       "-0.5 EUR",
+		"100 USD":        "100 USD",
+		big.NewRat(7, 4): "1.75",
+	} {
+		binary, err := mapper.Encode(map[string]any{"Price": input}, testMessage)
+		if err != nil {
+			t.Fatalf("%v: map input encoding failed: %v", input, err)
+		}
+
+		result, err := mapper.Decode(binary, testMessage)
+		if err != nil {
+			t.Fatalf("%v: binary data decoding failed: %v", input, err)
+		}
+
+		price := result.(map[string]any)["Price"]
+		if price != expected {
+			t.Fatalf("%v: expected %v, got %v", input, expected, price)
+		}
+	}
+}
+
+func TestGoogleType_Errors(t *testing.T) {
+	mapper := newTestMapper(t)
+
+	for _, invalid := range []map[string]any{
+		{"Date": "29.02.2024"},
+		{"Date": googletype.Date{Year: 2024, Month: 13}},
+		{"Time": "25:00"},
+		{"Time": googletype.TimeOfDay{Hours: 24, Minutes: 1}},
+		{"Price": googletype.Money{CurrencyCode: "USD", Amount: big.NewRat(1, 3)}},
+		{"Location": googletype.LatLng{Latitude: 91}},
+		{"Price": "12,34 USD"},
+		{"Price": (*big.Rat)(nil)},
+		{"Rate": "1,5"},
+		{"Rate": (*big.Float)(nil)},
+	} {
+		if _, err := mapper.Encode(invalid, testMessage); err == nil {
+			t.Fatalf("expected error for %v", invalid)
+		}
+	}
+
+	binary, err := mapper.Encode(map[string]any{"Date": googletype.Date{Year: 2024}}, testMessage)
+	if err != nil {
+		t.Fatalf("map input encoding failed: %v", err)
+	}
+
+	if _, err := mapper.Decode(binary, testMessage, googletype.DateTimeDecoder); err == nil {
+		t.Fatal("expected error for partial date decoded as time")
+	}
+}
+
+func TestGoogleType_JSONSchema(t *testing.T) {
+	mapper := newTestMapper(t)
+
+	schema, err := mapper.JSONSchema(testMessage)
+	if err != nil {
+		t.Fatalf("schema generation failed: %v", err)
+	}
+
+	props := schema["$defs"].(map[string]any)[testMessage].(map[string]any)["properties"].(map[string]any)
+	date := props["Date"].(map[string]any)["anyOf"].([]any)[0]
+	if expected := map[string]any{"type": "string", "format": "date"}; !reflect.DeepEqual(expected, date) {
+		t.Fatalf("expected date schema %v, got %v", expected, date)
+	}
+}
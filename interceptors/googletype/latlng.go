@@ -0,0 +1,58 @@
+package googletype
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const latLngName protoreflect.FullName = "google.type.LatLng"
+
+// LatLng is a pair of latitude and longitude in degrees, like google.type.LatLng.
+type LatLng struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// LatLngDecoder decodes google.type.LatLng to LatLng, or nil if field is absent.
+func LatLngDecoder(message protoreflect.Message) (result any, applied bool, err error) {
+	if message.Descriptor().FullName() != latLngName {
+		return nil, false, nil
+	}
+
+	if !message.IsValid() {
+		return nil, true, nil
+	}
+
+	fields := message.Descriptor().Fields()
+	return LatLng{
+		Latitude:  message.Get(fields.ByName("latitude")).Float(),
+		Longitude: message.Get(fields.ByName("longitude")).Float(),
+	}, true, nil
+}
+
+// LatLngEncoder encodes LatLng to google.type.LatLng, checking degrees ranges.
+func LatLngEncoder(input any, message protoreflect.Message) (applied bool, err error) {
+	if message.Descriptor().FullName() != latLngName {
+		return false, nil
+	}
+
+	var l LatLng
+	switch t := input.(type) {
+	case nil:
+		return true, nil
+	case LatLng:
+		l = t
+	default:
+		return false, nil
+	}
+
+	if !(l.Latitude >= -90 && l.Latitude <= 90) || !(l.Longitude >= -180 && l.Longitude <= 180) {
+		return true, fmt.Errorf("lat/lng %v/%v is out of range", l.Latitude, l.Longitude)
+	}
+
+	fields := message.Descriptor().Fields()
+	message.Set(fields.ByName("latitude"), protoreflect.ValueOfFloat64(l.Latitude))
+	message.Set(fields.ByName("longitude"), protoreflect.ValueOfFloat64(l.Longitude))
+	return true, nil
+}
@@ -0,0 +1,120 @@
+package googletype
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const moneyName protoreflect.FullName = "google.type.Money"
+
+// Money is an amount of money with its currency code, like google.type.Money.
+type Money struct {
+	CurrencyCode string
+	Amount       *big.Rat
+}
+
+// String returns money in the form MoneyEncoder accepts, like "12.34 USD".
+func (m Money) String() string {
+	amount := "0"
+	if m.Amount != nil {
+		amount = strings.TrimRight(strings.TrimRight(m.Amount.FloatString(9), "0"), ".")
+	}
+
+	if m.CurrencyCode == "" {
+		return amount
+	}
+	return amount + " " + m.CurrencyCode
+}
+
+// MoneyDecoder decodes google.type.Money to string of decimal amount and currency code, like "12.34 USD",
+// or nil if field is absent.
+func MoneyDecoder(message protoreflect.Message) (result any, applied bool, err error) {
+	result, applied, err = StructMoneyDecoder(message)
+	if result != nil {
+		result = result.(Money).String()
+	}
+	return result, applied, err
+}
+
+// StructMoneyDecoder decodes google.type.Money to Money, or nil if field is absent.
+func StructMoneyDecoder(message protoreflect.Message) (result any, applied bool, err error) {
+	if message.Descriptor().FullName() != moneyName {
+		return nil, false, nil
+	}
+
+	if !message.IsValid() {
+		return nil, true, nil
+	}
+
+	fields := message.Descriptor().Fields()
+	units := message.Get(fields.ByName("units")).Int()
+	nanos := message.Get(fields.ByName("nanos")).Int()
+
+	amount := new(big.Rat).SetInt64(units)
+	amount.Add(amount, big.NewRat(nanos, 1e9))
+
+	return Money{
+		CurrencyCode: message.Get(fields.ByName("currency_code")).String(),
+		Amount:       amount,
+	}, true, nil
+}
+
+// MoneyEncoder encodes Money, *big.Rat amount and decimal strings, like "12.34" or "12.34 USD",
+// to google.type.Money; amount must fit into units and nanos.
+func MoneyEncoder(input any, message protoreflect.Message) (applied bool, err error) {
+	if message.Descriptor().FullName() != moneyName {
+		return false, nil
+	}
+
+	var m Money
+	switch t := input.(type) {
+	case nil:
+		return true, nil
+	case Money:
+		m = t
+	case *Money:
+		if t == nil {
+			return true, nil
+		}
+		m = *t
+	case *big.Rat:
+		if t == nil {
+			return true, fmt.Errorf("nil *big.Rat cannot be converted to %v", moneyName)
+		}
+		m = Money{Amount: t}
+	case string:
+		amount, currency, _ := strings.Cut(strings.TrimSpace(t), " ")
+		rat, ok := new(big.Rat).SetString(amount)
+		if !ok {
+			return true, fmt.Errorf("cannot parse %q as money", t)
+		}
+		m = Money{CurrencyCode: strings.TrimSpace(currency), Amount: rat}
+	default:
+		return false, nil
+	}
+
+	if m.Amount == nil {
+		m.Amount = new(big.Rat)
+	}
+
+	// units are truncated towards zero, so nanos always have the same sign
+	units := new(big.Int).Quo(m.Amount.Num(), m.Amount.Denom())
+	if !units.IsInt64() {
+		return true, fmt.Errorf("money amount %v is out of range", m.Amount.FloatString(9))
+	}
+
+	fraction := new(big.Rat).Sub(m.Amount, new(big.Rat).SetInt(units))
+	fraction.Mul(fraction, big.NewRat(1e9, 1))
+	if !fraction.IsInt() {
+		return true, fmt.Errorf("money amount %v has more than 9 fractional digits", m.Amount.RatString())
+	}
+
+	fields := message.Descriptor().Fields()
+	message.Set(fields.ByName("currency_code"), protoreflect.ValueOfString(m.CurrencyCode))
+	message.Set(fields.ByName("units"), protoreflect.ValueOfInt64(units.Int64()))
+	message.Set(fields.ByName("nanos"), protoreflect.ValueOfInt32(int32(fraction.Num().Int64())))
+	return true, nil
+}
@@ -0,0 +1,36 @@
+package googletype
+
+import (
+	"github.com/gekatateam/protomap"
+)
+
+// Register registers default interceptors of google.type common types and their JSON Schemas:
+// Date, TimeOfDay, Money, LatLng and Decimal; Money and Decimal are decoded to strings.
+func Register(registry *protomap.Registry) {
+	registry.Register(string(dateName), DateEncoder, DateDecoder)
+	registry.Register(string(timeOfDayName), TimeOfDayEncoder, TimeOfDayDecoder)
+	registry.Register(string(moneyName), MoneyEncoder, MoneyDecoder)
+	registry.Register(string(latLngName), LatLngEncoder, LatLngDecoder)
+	registry.Register(string(decimalName), DecimalEncoder, DecimalDecoder)
+
+	registry.RegisterSchema(string(dateName), map[string]any{"type": "string", "format": "date"})
+	registry.RegisterSchema(string(timeOfDayName), map[string]any{
+		"type":    "string",
+		"pattern": `^\d{2}:\d{2}(:\d{2}(\.\d{1,9})?)?$`,
+	})
+	registry.RegisterSchema(string(moneyName), map[string]any{
+		"type":        "string",
+		"description": "decimal amount and currency code, like 12.34 USD",
+	})
+	registry.RegisterSchema(string(latLngName), map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"latitude":  map[string]any{"type": "number", "minimum": -90, "maximum": 90},
+			"longitude": map[string]any{"type": "number", "minimum": -180, "maximum": 180},
+		},
+	})
+	registry.RegisterSchema(string(decimalName), map[string]any{"anyOf": []any{
+		map[string]any{"type": "string", "pattern": decimalRe.String()},
+		map[string]any{"type": "number"},
+	}})
+}
@@ -0,0 +1,27 @@
+package interceptors
+
+import (
+	"github.com/gekatateam/protomap"
+)
+
+// RegisterWellKnown registers default interceptors of google.protobuf well-known types and their JSON Schemas,
+// except Any, which needs a Mapper; it may be registered like this:
+//
+//	mapper = mapper.WithRegistry(registry)
+//	registry.Register("google.protobuf.Any", AnyEncoder(mapper), AnyDecoder(mapper))
+//	registry.RegisterSchema("google.protobuf.Any", AnySchema())
+func RegisterWellKnown(registry *protomap.Registry) {
+	registry.Register("google.protobuf.Timestamp", TimeEncoder, TimeDecoder)
+	registry.Register("google.protobuf.Duration", DurationEncoder, DurationDecoder)
+	registry.Register(string(structName), StructEncoder, StructDecoder)
+	registry.Register(string(valueName), ValueEncoder, ValueDecoder)
+	registry.Register(string(listValueName), ListValueEncoder, ListValueDecoder)
+	registry.Register(string(fieldMaskName), FieldMaskEncoder, FieldMaskDecoder)
+	registry.Register(string(emptyName), EmptyEncoder, EmptyDecoder)
+
+	for name := range wrappers {
+		registry.Register(string(name), WrapperEncoder, WrapperDecoder)
+	}
+
+	registerSchemas(registry)
+}
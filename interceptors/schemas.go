@@ -0,0 +1,102 @@
+package interceptors
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/gekatateam/protomap"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// TimeSchema returns JSON Schema of google.protobuf.Timestamp as TimeEncoder accepts it.
+func TimeSchema() map[string]any {
+	return map[string]any{"type": "string", "format": "date-time"}
+}
+
+// UnixTimeSchema returns JSON Schema of google.protobuf.Timestamp as UnixTimeEncoder with the same unit accepts it.
+func UnixTimeSchema(unit time.Duration) map[string]any {
+	return map[string]any{"anyOf": []any{
+		TimeSchema(),
+		map[string]any{"type": "number", "description": fmt.Sprintf("Unix time in %v units", unit)},
+	}}
+}
+
+// DurationSchema returns JSON Schema of google.protobuf.Duration as DurationEncoder accepts it.
+func DurationSchema() map[string]any {
+	return map[string]any{"type": "string", "description": "protobuf JSON duration, like 1.5s, or Go duration, like 1h30m"}
+}
+
+// NumericDurationSchema returns JSON Schema of google.protobuf.Duration
+// as NumericDurationEncoder with the same unit accepts it.
+func NumericDurationSchema(unit time.Duration) map[string]any {
+	return map[string]any{"anyOf": []any{
+		DurationSchema(),
+		map[string]any{"type": "number", "description": fmt.Sprintf("amount of %v units", unit)},
+	}}
+}
+
+// AnySchema returns JSON Schema of google.protobuf.Any as AnyEncoder accepts it.
+func AnySchema() map[string]any {
+	return map[string]any{
+		"type":       "object",
+		"properties": map[string]any{anyTypeKey: map[string]any{"type": "string"}},
+		"required":   []any{anyTypeKey},
+	}
+}
+
+var wellKnownSchemas = map[protoreflect.FullName]func() map[string]any{
+	"google.protobuf.Timestamp": TimeSchema,
+	"google.protobuf.Duration":  DurationSchema,
+	structName: func() map[string]any {
+		return map[string]any{"type": "object"}
+	},
+	valueName: func() map[string]any {
+		return map[string]any{}
+	},
+	listValueName: func() map[string]any {
+		return map[string]any{"type": "array"}
+	},
+	fieldMaskName: func() map[string]any {
+		return map[string]any{"anyOf": []any{
+			map[string]any{"type": "string", "description": "comma-separated lowerCamelCase paths"},
+			map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+		}}
+	},
+	emptyName: func() map[string]any {
+		return map[string]any{"type": "object", "maxProperties": 0}
+	},
+	"google.protobuf.DoubleValue": func() map[string]any {
+		return map[string]any{"type": "number"}
+	},
+	"google.protobuf.FloatValue": func() map[string]any {
+		return map[string]any{"type": "number"}
+	},
+	"google.protobuf.Int64Value": func() map[string]any {
+		return map[string]any{"type": "integer"}
+	},
+	"google.protobuf.UInt64Value": func() map[string]any {
+		return map[string]any{"type": "integer", "minimum": 0}
+	},
+	"google.protobuf.Int32Value": func() map[string]any {
+		return map[string]any{"type": "integer", "minimum": math.MinInt32, "maximum": math.MaxInt32}
+	},
+	"google.protobuf.UInt32Value": func() map[string]any {
+		return map[string]any{"type": "integer", "minimum": 0, "maximum": math.MaxUint32}
+	},
+	"google.protobuf.BoolValue": func() map[string]any {
+		return map[string]any{"type": "boolean"}
+	},
+	"google.protobuf.StringValue": func() map[string]any {
+		return map[string]any{"type": "string"}
+	},
+	"google.protobuf.BytesValue": func() map[string]any {
+		return map[string]any{"type": "string"}
+	},
+}
+
+func registerSchemas(registry *protomap.Registry) {
+	for name, schema := range wellKnownSchemas {
+		registry.RegisterSchema(string(name), schema())
+	}
+}
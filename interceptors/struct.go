@@ -0,0 +1,193 @@
+package interceptors
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/gekatateam/protomap"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	structName    protoreflect.FullName = "google.protobuf.Struct"
+	valueName     protoreflect.FullName = "google.protobuf.Value"
+	listValueName protoreflect.FullName = "google.protobuf.ListValue"
+)
+
+// StructDecoder decodes google.protobuf.Struct to map[string]any, or nil if field is absent.
+func StructDecoder(message protoreflect.Message) (result any, applied bool, err error) {
+	if message.Descriptor().FullName() != structName {
+		return nil, false, nil
+	}
+
+	if !message.IsValid() {
+		return nil, true, nil
+	}
+
+	return structToMap(message), true, nil
+}
+
+// ValueDecoder decodes google.protobuf.Value to nil, float64, string, bool, []any or map[string]any.
+func ValueDecoder(message protoreflect.Message) (result any, applied bool, err error) {
+	if message.Descriptor().FullName() != valueName {
+		return nil, false, nil
+	}
+
+	return valueToAny(message), true, nil
+}
+
+// ListValueDecoder decodes google.protobuf.ListValue to []any, or nil if field is absent.
+func ListValueDecoder(message protoreflect.Message) (result any, applied bool, err error) {
+	if message.Descriptor().FullName() != listValueName {
+		return nil, false, nil
+	}
+
+	if !message.IsValid() {
+		return nil, true, nil
+	}
+
+	return listToSlice(message), true, nil
+}
+
+// StructEncoder encodes map[string]any to google.protobuf.Struct.
+func StructEncoder(input any, message protoreflect.Message) (applied bool, err error) {
+	if message.Descriptor().FullName() != structName {
+		return false, nil
+	}
+
+	if input == nil {
+		return true, nil
+	}
+
+	data, ok := input.(map[string]any)
+	if !ok {
+		return true, fmt.Errorf("cannot convert %T to %v", input, structName)
+	}
+
+	return true, mapToStruct(data, message)
+}
+
+// ValueEncoder encodes nil, numbers, strings, booleans, []any and map[string]any to google.protobuf.Value.
+func ValueEncoder(input any, message protoreflect.Message) (applied bool, err error) {
+	if message.Descriptor().FullName() != valueName {
+		return false, nil
+	}
+
+	return true, anyToValue(input, message)
+}
+
+// ListValueEncoder encodes []any to google.protobuf.ListValue.
+func ListValueEncoder(input any, message protoreflect.Message) (applied bool, err error) {
+	if message.Descriptor().FullName() != listValueName {
+		return false, nil
+	}
+
+	if input == nil {
+		return true, nil
+	}
+
+	slice, ok := input.([]any)
+	if !ok {
+		return true, fmt.Errorf("cannot convert %T to %v", input, listValueName)
+	}
+
+	return true, sliceToList(slice, message)
+}
+
+func structToMap(message protoreflect.Message) map[string]any {
+	fields := message.Get(message.Descriptor().Fields().ByName("fields")).Map()
+	result := make(map[string]any, fields.Len())
+	fields.Range(func(mk protoreflect.MapKey, v protoreflect.Value) bool {
+		result[mk.String()] = valueToAny(v.Message())
+		return true
+	})
+	return result
+}
+
+func listToSlice(message protoreflect.Message) []any {
+	values := message.Get(message.Descriptor().Fields().ByName("values")).List()
+	result := make([]any, 0, values.Len())
+	for i := 0; i < values.Len(); i++ {
+		result = append(result, valueToAny(values.Get(i).Message()))
+	}
+	return result
+}
+
+func valueToAny(message protoreflect.Message) any {
+	field := message.WhichOneof(message.Descriptor().Oneofs().ByName("kind"))
+	if field == nil {
+		return nil
+	}
+
+	value := message.Get(field)
+	switch field.Name() {
+	case "number_value":
+		return value.Float()
+	case "string_value":
+		return value.String()
+	case "bool_value":
+		return value.Bool()
+	case "struct_value":
+		return structToMap(value.Message())
+	case "list_value":
+		return listToSlice(value.Message())
+	default: // null_value
+		return nil
+	}
+}
+
+func mapToStruct(data map[string]any, message protoreflect.Message) error {
+	fields := message.Mutable(message.Descriptor().Fields().ByName("fields")).Map()
+	for k, v := range data {
+		value := fields.NewValue()
+		if err := anyToValue(v, value.Message()); err != nil {
+			return fmt.Errorf("%v: %w", k, err)
+		}
+		fields.Set(protoreflect.ValueOfString(k).MapKey(), value)
+	}
+	return nil
+}
+
+func sliceToList(slice []any, message protoreflect.Message) error {
+	values := message.Mutable(message.Descriptor().Fields().ByName("values")).List()
+	for i, v := range slice {
+		value := values.NewElement()
+		if err := anyToValue(v, value.Message()); err != nil {
+			return fmt.Errorf("%v: %w", i, err)
+		}
+		values.Append(value)
+	}
+	return nil
+}
+
+func anyToValue(input any, message protoreflect.Message) error {
+	fields := message.Descriptor().Fields()
+
+	switch t := input.(type) {
+	case nil:
+		message.Set(fields.ByName("null_value"), protoreflect.ValueOfEnum(0))
+	case string:
+		message.Set(fields.ByName("string_value"), protoreflect.ValueOfString(t))
+	case bool:
+		message.Set(fields.ByName("bool_value"), protoreflect.ValueOfBool(t))
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		f, err := protomap.AnyToFloat(t)
+		if err != nil {
+			return err
+		}
+
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return fmt.Errorf("cannot convert %v to %v", f, valueName)
+		}
+
+		message.Set(fields.ByName("number_value"), protoreflect.ValueOfFloat64(f))
+	case map[string]any:
+		return mapToStruct(t, message.Mutable(fields.ByName("struct_value")).Message())
+	case []any:
+		return sliceToList(t, message.Mutable(fields.ByName("list_value")).Message())
+	default:
+		return fmt.Errorf("cannot convert %T to %v", input, valueName)
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package interceptors
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	fieldMaskName protoreflect.FullName = "google.protobuf.FieldMask"
+	emptyName     protoreflect.FullName = "google.protobuf.Empty"
+)
+
+// FieldMaskDecoder decodes google.protobuf.FieldMask to []string of paths, or nil if field is absent.
+func FieldMaskDecoder(message protoreflect.Message) (result any, applied bool, err error) {
+	if message.Descriptor().FullName() != fieldMaskName {
+		return nil, false, nil
+	}
+
+	if !message.IsValid() {
+		return nil, true, nil
+	}
+
+	paths := message.Get(message.Descriptor().Fields().ByName("paths")).List()
+	slice := make([]string, 0, paths.Len())
+	for i := 0; i < paths.Len(); i++ {
+		slice = append(slice, paths.Get(i).String())
+	}
+
+	return slice, true, nil
+}
+
+// FieldMaskEncoder encodes []string, []any of strings, or comma-separated string to google.protobuf.FieldMask.
+// Comma-separated string is the JSON form, so its lowerCamelCase paths are converted to proto names
+// following protojson rules, like "fooBar,inner.bazQux" to "foo_bar" and "inner.baz_qux".
+func FieldMaskEncoder(input any, message protoreflect.Message) (applied bool, err error) {
+	if message.Descriptor().FullName() != fieldMaskName {
+		return false, nil
+	}
+
+	var paths []string
+	switch t := input.(type) {
+	case nil:
+		return true, nil
+	case string:
+		if t == "" {
+			break
+		}
+
+		for _, path := range strings.Split(t, ",") {
+			path, err := jsonPathToProto(strings.TrimSpace(path))
+			if err != nil {
+				return true, err
+			}
+			paths = append(paths, path)
+		}
+	case []string:
+		paths = t
+	case []any:
+		for i, v := range t {
+			path, ok := v.(string)
+			if !ok {
+				return true, fmt.Errorf("%v: path must be a string, got %T", i, v)
+			}
+			paths = append(paths, path)
+		}
+	case map[string]any:
+		return false, nil
+	default:
+		return true, fmt.Errorf("cannot convert %T to %v", input, fieldMaskName)
+	}
+
+	list := message.Mutable(message.Descriptor().Fields().ByName("paths")).List()
+	for _, path := range paths {
+		list.Append(protoreflect.ValueOfString(strings.TrimSpace(path)))
+	}
+
+	return true, nil
+}
+
+// jsonPathToProto converts lowerCamelCase path of JSON form to snake_case one.
+func jsonPathToProto(path string) (string, error) {
+	// underscores are not allowed in JSON form, because conversion cannot be reversed then
+	if strings.Contains(path, "_") {
+		return "", fmt.Errorf("invalid path %q in JSON form", path)
+	}
+
+	var b strings.Builder
+	for _, c := range []byte(path) {
+		if c >= 'A' && c <= 'Z' {
+			b.WriteByte('_')
+			c += 'a' - 'A'
+		}
+		b.WriteByte(c)
+	}
+
+	result := b.String()
+	if !protoreflect.FullName(result).IsValid() {
+		return "", fmt.Errorf("invalid path %q", path)
+	}
+	return result, nil
+}
+
+// EmptyDecoder decodes google.protobuf.Empty to empty map, or nil if field is absent.
+func EmptyDecoder(message protoreflect.Message) (result any, applied bool, err error) {
+	if message.Descriptor().FullName() != emptyName {
+		return nil, false, nil
+	}
+
+	if !message.IsValid() {
+		return nil, true, nil
+	}
+
+	return map[string]any{}, true, nil
+}
+
+// EmptyEncoder encodes empty map or struct{} to google.protobuf.Empty; nil leaves field absent.
+func EmptyEncoder(input any, message protoreflect.Message) (applied bool, err error) {
+	if message.Descriptor().FullName() != emptyName {
+		return false, nil
+	}
+
+	switch t := input.(type) {
+	case nil, struct{}:
+		return true, nil
+	case map[string]any:
+		if len(t) > 0 {
+			return true, fmt.Errorf("%v cannot have fields, got %v keys", emptyName, len(t))
+		}
+		return true, nil
+	default:
+		return true, fmt.Errorf("cannot convert %T to %v", input, emptyName)
+	}
+}
@@ -0,0 +1,64 @@
+package interceptors
+
+import (
+	"github.com/gekatateam/protomap"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var wrappers = map[protoreflect.FullName]struct{}{
+	"google.protobuf.DoubleValue": {},
+	"google.protobuf.FloatValue":  {},
+	"google.protobuf.Int64Value":  {},
+	"google.protobuf.UInt64Value": {},
+	"google.protobuf.Int32Value":  {},
+	"google.protobuf.UInt32Value": {},
+	"google.protobuf.BoolValue":   {},
+	"google.protobuf.StringValue": {},
+	"google.protobuf.BytesValue":  {},
+}
+
+func isWrapper(message protoreflect.Message) bool {
+	_, ok := wrappers[message.Descriptor().FullName()]
+	return ok
+}
+
+// WrapperDecoder decodes google.protobuf wrapper types to plain Go scalars, or nil if field is absent.
+// Scalar types are the same as for regular fields of wrapped kind.
+func WrapperDecoder(message protoreflect.Message) (result any, applied bool, err error) {
+	if !isWrapper(message) {
+		return nil, false, nil
+	}
+
+	if !message.IsValid() {
+		return nil, true, nil
+	}
+
+	field := message.Descriptor().Fields().ByName("value")
+	result, err = protomap.ProtoToGoValue(field, field.Kind(), message.Get(field))
+	return result, true, err
+}
+
+// WrapperEncoder encodes plain Go scalars to google.protobuf wrapper types; nil leaves field absent.
+// Maps are not intercepted, so {"value": ...} form is still accepted.
+func WrapperEncoder(input any, message protoreflect.Message) (applied bool, err error) {
+	if !isWrapper(message) {
+		return false, nil
+	}
+
+	if input == nil {
+		return true, nil
+	}
+
+	if _, ok := input.(map[string]any); ok {
+		return false, nil
+	}
+
+	field := message.Descriptor().Fields().ByName("value")
+	value, err := protomap.GoValueToProto(field, field.Kind(), input)
+	if err != nil {
+		return true, err
+	}
+
+	message.Set(field, value)
+	return true, nil
+}
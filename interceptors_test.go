@@ -0,0 +1,471 @@
+package protomap_test
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/gekatateam/protomap"
+	"github.com/gekatateam/protomap/interceptors"
+)
+
+func TestInterceptors_Wrappers(t *testing.T) {
+	mapper := newWellKnownMapper(t)
+
+	input := map[string]any{
+		"String": "",
+		"Int":    "42",
+		"Uint":   nil,
+		"Double": 1.5,
+		"Bool":   map[string]any{"value": true},
+		"List":   []any{"a", "b"},
+	}
+
+	binary, err := mapper.Encode(input, "protomap.test.WithWrappers", interceptors.WrapperEncoder)
+	if err != nil {
+		t.Fatalf("map input encoding failed: %v", err)
+	}
+
+	result, err := mapper.Decode(binary, "protomap.test.WithWrappers", interceptors.WrapperDecoder)
+	if err != nil {
+		t.Fatalf("binary data decoding failed: %v", err)
+	}
+
+	expected := map[string]any{
+		"String": "",
+		"Int":    int64(42),
+		"Uint":   nil,
+		"Double": 1.5,
+		"Bool":   true,
+		"Bytes":  nil,
+		"List":   []any{"a", "b"},
+	}
+
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	if _, err := mapper.Encode(map[string]any{"Int": "nan", "List": []any{}}, "protomap.test.WithWrappers", interceptors.WrapperEncoder); err == nil {
+		t.Fatal("expected error for non-integer wrapper value")
+	}
+}
+
+func TestInterceptors_Struct(t *testing.T) {
+	mapper := newWellKnownMapper(t)
+	encoders := []protomap.EncodeInterceptor{interceptors.StructEncoder, interceptors.ValueEncoder, interceptors.ListValueEncoder}
+	decoders := []protomap.DecodeInterceptor{interceptors.StructDecoder, interceptors.ValueDecoder, interceptors.ListValueDecoder}
+
+	input := map[string]any{
+		"Struct": map[string]any{
+			"string": "foo",
+			"number": 42,
+			"bool":   true,
+			"null":   nil,
+			"list":   []any{"a", 1.5, map[string]any{}},
+			"nested": map[string]any{"key": "value"},
+		},
+		"Value": []any{false, nil},
+		"List":  []any{},
+		"Null":  nil,
+	}
+
+	binary, err := mapper.Encode(input, "protomap.test.WithStruct", encoders...)
+	if err != nil {
+		t.Fatalf("map input encoding failed: %v", err)
+	}
+
+	result, err := mapper.Decode(binary, "protomap.test.WithStruct", decoders...)
+	if err != nil {
+		t.Fatalf("binary data decoding failed: %v", err)
+	}
+
+	expected := map[string]any{
+		"Struct": map[string]any{
+			"string": "foo",
+			"number": float64(42),
+			"bool":   true,
+			"null":   nil,
+			"list":   []any{"a", 1.5, map[string]any{}},
+			"nested": map[string]any{"key": "value"},
+		},
+		"Value": []any{false, nil},
+		"List":  []any{},
+		"Null":  nil,
+	}
+
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	for _, invalid := range []map[string]any{
+		{"Value": struct{}{}},
+		{"Value": math.Inf(1)},
+		{"Struct": []any{}},
+		{"List": map[string]any{}},
+	} {
+		if _, err := mapper.Encode(invalid, "protomap.test.WithStruct", encoders...); err == nil {
+			t.Fatalf("expected error for %v", invalid)
+		}
+	}
+}
+
+func TestInterceptors_Any(t *testing.T) {
+	mapper := newWellKnownMapper(t)
+	encoder := interceptors.AnyEncoder(mapper, interceptors.WrapperEncoder)
+	decoder := interceptors.AnyDecoder(mapper, interceptors.WrapperDecoder)
+
+	input := map[string]any{
+		"Any": map[string]any{
+			"@type":  "protomap.test.WithWrappers",
+			"String": "foo",
+			"List":   []any{},
+		},
+		"List": []any{
+			map[string]any{
+				"@type": "type.googleapis.com/protomap.test.WithAny",
+				"Any": map[string]any{
+					"@type":  "protomap.test.WithWrappers",
+					"Double": 2.5,
+					"List":   []any{"x"},
+				},
+				"List": []any{},
+			},
+		},
+	}
+
+	binary, err := mapper.Encode(input, "protomap.test.WithAny", encoder)
+	if err != nil {
+		t.Fatalf("map input encoding failed: %v", err)
+	}
+
+	result, err := mapper.Decode(binary, "protomap.test.WithAny", decoder)
+	if err != nil {
+		t.Fatalf("binary data decoding failed: %v", err)
+	}
+
+	wrappers := func(fields map[string]any) map[string]any {
+		result := map[string]any{
+			"@type":  "type.googleapis.com/protomap.test.WithWrappers",
+			"String": nil,
+			"Int":    nil,
+			"Uint":   nil,
+			"Double": nil,
+			"Bool":   nil,
+			"Bytes":  nil,
+			"List":   []any{},
+		}
+		for k, v := range fields {
+			result[k] = v
+		}
+		return result
+	}
+
+	expected := map[string]any{
+		"Any": wrappers(map[string]any{"String": "foo"}),
+		"List": []any{
+			map[string]any{
+				"@type": "type.googleapis.com/protomap.test.WithAny",
+				"Any":   wrappers(map[string]any{"Double": 2.5, "List": []any{"x"}}),
+				"List":  []any{},
+				"Empty": nil,
+			},
+		},
+		"Empty": nil,
+	}
+
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	unknown := map[string]any{"Any": map[string]any{"@type": "protomap.test.Unknown"}, "List": []any{}}
+	if _, err := mapper.Encode(unknown, "protomap.test.WithAny", encoder); !errors.Is(err, protomap.ErrNoSuchMessage) {
+		t.Fatalf("expected no such message error, got %v", err)
+	}
+
+	untyped := map[string]any{"Any": map[string]any{"String": "foo"}, "List": []any{}}
+	if _, err := mapper.Encode(untyped, "protomap.test.WithAny", encoder); err == nil {
+		t.Fatal("expected error for map without @type key")
+	}
+}
+
+func TestInterceptors_FieldMaskEmpty(t *testing.T) {
+	mapper := newWellKnownMapper(t)
+	encoders := []protomap.EncodeInterceptor{interceptors.FieldMaskEncoder, interceptors.EmptyEncoder}
+	decoders := []protomap.DecodeInterceptor{interceptors.FieldMaskDecoder, interceptors.EmptyDecoder}
+
+	input := map[string]any{
+		"Mask":        []any{"Inner.Foo", "String"},
+		"JsonMask":    "fooBar, inner.bazQux",
+		"NoMask":      nil,
+		"Empty":       map[string]any{},
+		"EmptyStruct": struct{}{},
+		"NoEmpty":     nil,
+	}
+
+	binary, err := mapper.Encode(input, "protomap.test.WithFieldMaskEmpty", encoders...)
+	if err != nil {
+		t.Fatalf("map input encoding failed: %v", err)
+	}
+
+	result, err := mapper.Decode(binary, "protomap.test.WithFieldMaskEmpty", decoders...)
+	if err != nil {
+		t.Fatalf("binary data decoding failed: %v", err)
+	}
+
+	expected := map[string]any{
+		"Mask":        []string{"Inner.Foo", "String"},
+		"JsonMask":    []string{"foo_bar", "inner.baz_qux"},
+		"NoMask":      nil,
+		"Empty":       map[string]any{},
+		"EmptyStruct": map[string]any{},
+		"NoEmpty":     nil,
+	}
+
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	for _, invalid := range []map[string]any{
+		{"Mask": []any{1}},
+		{"Mask": 1},
+		{"JsonMask": "foo_bar"},
+		{"JsonMask": "inner..bazQux"},
+		{"Empty": map[string]any{"key": "value"}},
+	} {
+		if _, err := mapper.Encode(invalid, "protomap.test.WithFieldMaskEmpty", encoders...); err == nil {
+			t.Fatalf("expected error for %v", invalid)
+		}
+	}
+}
+
+func TestInterceptors_FlexibleTimeDuration(t *testing.T) {
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{}),
+	}
+
+	mapper, err := protomap.NewMapper(&compiler, testIntersProto)
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	encoders := []protomap.EncodeInterceptor{interceptors.UnixTimeEncoder(time.Millisecond), interceptors.NumericDurationEncoder(time.Second)}
+	decoders := []protomap.DecodeInterceptor{interceptors.TimeDecoder, interceptors.DurationDecoder}
+
+	cases := []struct {
+		name        string
+		ts          any
+		dur         any
+		expectedTs  time.Time
+		expectedDur time.Duration
+	}{
+		{
+			name:        "rfc3339 and proto json",
+			ts:          "2024-05-01T10:20:30.5+03:00",
+			dur:         "1.5s",
+			expectedTs:  time.Date(2024, 5, 1, 7, 20, 30, 5e8, time.UTC),
+			expectedDur: 1500 * time.Millisecond,
+		},
+		{
+			name:        "iso8601 without zone and go duration",
+			ts:          "2024-05-01T10:20:30",
+			dur:         "-1h30m",
+			expectedTs:  time.Date(2024, 5, 1, 10, 20, 30, 0, time.UTC),
+			expectedDur: -90 * time.Minute,
+		},
+		{
+			name:        "date and negative proto json",
+			ts:          "2024-05-01",
+			dur:         "-0.000000001s",
+			expectedTs:  time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+			expectedDur: -time.Nanosecond,
+		},
+		{
+			name:        "numbers",
+			ts:          int64(-1500),
+			dur:         2.25,
+			expectedTs:  time.Unix(-2, 5e8).UTC(),
+			expectedDur: 2250 * time.Millisecond,
+		},
+		{
+			name:        "go types",
+			ts:          time.Unix(1700000000, 42).UTC(),
+			dur:         time.Minute,
+			expectedTs:  time.Unix(1700000000, 42).UTC(),
+			expectedDur: time.Minute,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			binary, err := mapper.Encode(map[string]any{"Ts": c.ts, "Dur": c.dur}, testIntersMessage, encoders...)
+			if err != nil {
+				t.Fatalf("map input encoding failed: %v", err)
+			}
+
+			result, err := mapper.Decode(binary, testIntersMessage, decoders...)
+			if err != nil {
+				t.Fatalf("binary data decoding failed: %v", err)
+			}
+
+			expected := map[string]any{"Ts": c.expectedTs, "Dur": c.expectedDur}
+			if !reflect.DeepEqual(expected, result) {
+				t.Fatalf("expected %v, got %v", expected, result)
+			}
+		})
+	}
+
+	for _, invalid := range []map[string]any{
+		{"Ts": "yesterday"},
+		{"Ts": "10000-01-01T00:00:00Z"},
+		{"Ts": time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"Dur": "forever"},
+		{"Dur": "315576000001s"},
+	} {
+		if _, err := mapper.Encode(invalid, testIntersMessage, encoders...); err == nil {
+			t.Fatalf("expected error for %v", invalid)
+		}
+	}
+
+	if _, err := mapper.Encode(map[string]any{"Ts": 1700000000}, testIntersMessage, interceptors.TimeEncoder); err == nil {
+		t.Fatal("expected error for number without unix unit")
+	}
+}
+
+func TestInterceptors_NumericUnits(t *testing.T) {
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{}),
+	}
+
+	mapper, err := protomap.NewMapper(&compiler, testIntersProto)
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	decoders := []protomap.DecodeInterceptor{interceptors.TimeDecoder, interceptors.DurationDecoder}
+
+	cases := []struct {
+		name        string
+		unit        time.Duration
+		value       int64
+		expectedTs  time.Time
+		expectedDur time.Duration
+	}{
+		{
+			name:        "fraction of second",
+			unit:        300 * time.Millisecond,
+			value:       4,
+			expectedTs:  time.Unix(1, 2e8).UTC(),
+			expectedDur: 1200 * time.Millisecond,
+		},
+		{
+			name:        "more than second",
+			unit:        1500 * time.Millisecond,
+			value:       4,
+			expectedTs:  time.Unix(6, 0).UTC(),
+			expectedDur: 6 * time.Second,
+		},
+		{
+			name:        "negative",
+			unit:        300 * time.Millisecond,
+			value:       -4,
+			expectedTs:  time.Unix(-2, 8e8).UTC(),
+			expectedDur: -1200 * time.Millisecond,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			encoders := []protomap.EncodeInterceptor{interceptors.UnixTimeEncoder(c.unit), interceptors.NumericDurationEncoder(c.unit)}
+			binary, err := mapper.Encode(map[string]any{"Ts": c.value, "Dur": c.value}, testIntersMessage, encoders...)
+			if err != nil {
+				t.Fatalf("map input encoding failed: %v", err)
+			}
+
+			result, err := mapper.Decode(binary, testIntersMessage, decoders...)
+			if err != nil {
+				t.Fatalf("binary data decoding failed: %v", err)
+			}
+
+			expected := map[string]any{"Ts": c.expectedTs, "Dur": c.expectedDur}
+			if !reflect.DeepEqual(expected, result) {
+				t.Fatalf("expected %v, got %v", expected, result)
+			}
+		})
+	}
+}
+
+func TestInterceptors_TimeDurationDecodeFormats(t *testing.T) {
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{}),
+	}
+
+	mapper, err := protomap.NewMapper(&compiler, testIntersProto)
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	ts := time.Date(2025, 7, 30, 10, 1, 15, 250e6, time.UTC)
+
+	cases := []struct {
+		name     string
+		dur      any
+		decoders []protomap.DecodeInterceptor
+		expected map[string]any
+	}{
+		{
+			name:     "rfc3339 and string",
+			dur:      "13.5s",
+			decoders: []protomap.DecodeInterceptor{interceptors.RFC3339TimeDecoder, interceptors.StringDurationDecoder},
+			expected: map[string]any{"Ts": "2025-07-30T10:01:15.25Z", "Dur": "13.500s"},
+		},
+		{
+			name:     "unix millis and seconds",
+			dur:      "-13.5s",
+			decoders: []protomap.DecodeInterceptor{interceptors.UnixMillisTimeDecoder, interceptors.SecondsDurationDecoder},
+			expected: map[string]any{"Ts": ts.UnixMilli(), "Dur": -13.5},
+		},
+		{
+			name:     "location and strict",
+			dur:      "13s",
+			decoders: []protomap.DecodeInterceptor{interceptors.TimeDecoderIn(loc), interceptors.StrictDurationDecoder},
+			expected: map[string]any{"Ts": ts.In(loc), "Dur": 13 * time.Second},
+		},
+		{
+			name:     "clamped overflow",
+			dur:      "315576000000s",
+			decoders: []protomap.DecodeInterceptor{interceptors.TimeDecoder, interceptors.DurationDecoder},
+			expected: map[string]any{"Ts": ts, "Dur": time.Duration(math.MaxInt64)},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			binary, err := mapper.Encode(map[string]any{"Ts": ts, "Dur": c.dur}, testIntersMessage, interceptors.TimeEncoder, interceptors.DurationEncoder)
+			if err != nil {
+				t.Fatalf("map input encoding failed: %v", err)
+			}
+
+			result, err := mapper.Decode(binary, testIntersMessage, c.decoders...)
+			if err != nil {
+				t.Fatalf("binary data decoding failed: %v", err)
+			}
+
+			if !reflect.DeepEqual(c.expected, result) {
+				t.Fatalf("expected %v, got %v", c.expected, result)
+			}
+		})
+	}
+
+	binary, err := mapper.Encode(map[string]any{"Ts": ts, "Dur": "-315576000000s"}, testIntersMessage, interceptors.TimeEncoder, interceptors.DurationEncoder)
+	if err != nil {
+		t.Fatalf("map input encoding failed: %v", err)
+	}
+
+	if _, err := mapper.Decode(binary, testIntersMessage, interceptors.StrictDurationDecoder); !errors.Is(err, interceptors.ErrDurationOverflow) {
+		t.Fatalf("expected duration overflow error, got %v", err)
+	}
+}
@@ -0,0 +1,265 @@
+package protomap
+
+import (
+	"math"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema returns JSON Schema (draft 2020-12) of map, that Encode accepts and Decode returns, for message.
+// Messages are described in "$defs" by full name and referenced from fields; enums accept both names and numbers.
+// Messages registered in registry are described by schemas set with Registry.RegisterSchema,
+// or accept any value without it.
+func (m *Mapper) JSONSchema(messageName string) (map[string]any, error) {
+	desc, err := m.findMessage(messageName)
+	if err != nil {
+		return nil, err
+	}
+
+	g := newSchemaGenerator(m, "#/$defs/")
+	schema := g.messageSchema(desc)
+	schema["$schema"] = jsonSchemaDraft
+	schema["$defs"] = g.defs
+	return schema, nil
+}
+
+type schemaGenerator struct {
+	registry  *Registry
+	options   *optionsCache
+	refPrefix string
+	defs      map[string]any
+}
+
+func newSchemaGenerator(m *Mapper, refPrefix string) *schemaGenerator {
+	return &schemaGenerator{
+		registry:  m.registry,
+		options:   m.options,
+		refPrefix: refPrefix,
+		defs:      make(map[string]any),
+	}
+}
+
+// messageSchema returns reference to message definition, adding it to defs, if needed,
+// or inline schema of registered message.
+func (g *schemaGenerator) messageSchema(desc protoreflect.MessageDescriptor) map[string]any {
+	if g.registry != nil {
+		if _, ok := g.registry.encoders[desc.FullName()]; ok {
+			if schema, ok := g.registry.schemas[desc.FullName()]; ok {
+				return cloneSchema(schema).(map[string]any)
+			}
+			return map[string]any{}
+		}
+	}
+
+	name := string(desc.FullName())
+	if _, ok := g.defs[name]; !ok {
+		// placeholder breaks recursion
+		g.defs[name] = nil
+		g.defs[name] = g.messageDef(desc)
+	}
+
+	return map[string]any{"$ref": g.refPrefix + name}
+}
+
+func (g *schemaGenerator) messageDef(desc protoreflect.MessageDescriptor) map[string]any {
+	fields := desc.Fields()
+	omitEmpty := g.options.get(desc).omitEmpty
+	properties := make(map[string]any, fields.Len())
+	required := []any{}
+
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		opts := g.options.get(field)
+		if opts.skip {
+			continue
+		}
+
+		schema := g.fieldSchema(field)
+		if opts.hasDefault {
+			if v, err := (encoder{}).kindToProto(field, field.Kind(), opts.def, ""); err == nil && field.Kind() != protoreflect.EnumKind {
+				schema["default"] = v.Interface()
+			} else {
+				schema["default"] = opts.def
+			}
+		}
+
+		properties[string(field.Name())] = schema
+		if field.Cardinality() != protoreflect.Optional && !omitEmpty && !(opts.hasDefault && !field.IsList() && !field.IsMap()) {
+			required = append(required, string(field.Name()))
+		}
+	}
+
+	def := map[string]any{
+		"type":                 "object",
+		"title":                string(desc.FullName()),
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+
+	if len(required) > 0 {
+		def["required"] = required
+	}
+
+	if description := descriptorComments(desc); description != "" {
+		def["description"] = description
+	}
+
+	// every oneof allows exactly one of its fields, or none of them
+	var allOf []any
+	oneOfs := desc.Oneofs()
+	for i := 0; i < oneOfs.Len(); i++ {
+		oneOf := oneOfs.Get(i)
+		if oneOf.IsSynthetic() {
+			continue
+		}
+
+		var variants, present []any
+		oneOfFields := oneOf.Fields()
+		for j := 0; j < oneOfFields.Len(); j++ {
+			if g.options.get(oneOfFields.Get(j)).skip {
+				continue
+			}
+
+			variant := map[string]any{"required": []any{string(oneOfFields.Get(j).Name())}}
+			variants = append(variants, variant)
+			present = append(present, variant)
+		}
+
+		if len(variants) == 0 {
+			continue
+		}
+
+		variants = append(variants, map[string]any{"not": map[string]any{"anyOf": present}})
+		allOf = append(allOf, map[string]any{"oneOf": variants})
+	}
+
+	if len(allOf) > 0 {
+		def["allOf"] = allOf
+	}
+
+	return def
+}
+
+func (g *schemaGenerator) fieldSchema(field protoreflect.FieldDescriptor) map[string]any {
+	var schema map[string]any
+	switch {
+	case field.IsList():
+		schema = map[string]any{"type": "array", "items": g.valueSchema(field, field.Kind(), field.Message())}
+	case field.IsMap():
+		schema = map[string]any{
+			"type":                 "object",
+			"additionalProperties": g.valueSchema(field, field.MapValue().Kind(), field.MapValue().Message()),
+		}
+		if keys := mapKeySchema(field.MapKey().Kind()); keys != nil {
+			schema["propertyNames"] = keys
+		}
+	case field.Message() != nil:
+		// nil message leaves field absent
+		schema = map[string]any{"anyOf": []any{
+			g.valueSchema(field, field.Kind(), field.Message()),
+			map[string]any{"type": "null"},
+		}}
+	default:
+		schema = g.valueSchema(field, field.Kind(), nil)
+	}
+
+	if description := descriptorComments(field); description != "" {
+		schema["description"] = description
+	}
+
+	return schema
+}
+
+func (g *schemaGenerator) valueSchema(field protoreflect.FieldDescriptor, kind protoreflect.Kind, message protoreflect.MessageDescriptor) map[string]any {
+	if opts := g.options.get(field); opts.hasFormat && kind == protoreflect.BytesKind {
+		switch opts.format {
+		case "uuid":
+			// unset field is an empty string
+			return map[string]any{"type": "string", "anyOf": []any{
+				map[string]any{"format": "uuid"},
+				map[string]any{"maxLength": 0},
+			}}
+		case "hex":
+			return map[string]any{"type": "string", "pattern": "^([0-9a-fA-F]{2})*$"}
+		case "base64":
+			return map[string]any{"type": "string", "contentEncoding": "base64"}
+		}
+	}
+
+	switch kind {
+	case protoreflect.BoolKind:
+		return map[string]any{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return map[string]any{"type": "integer", "minimum": math.MinInt32, "maximum": math.MaxInt32}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return map[string]any{"type": "integer", "minimum": 0, "maximum": math.MaxUint32}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return map[string]any{"type": "integer"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return map[string]any{"type": "number"}
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		return map[string]any{"type": "string"}
+	case protoreflect.EnumKind:
+		enumDesc := field.Enum()
+		if field.IsMap() {
+			enumDesc = field.MapValue().Enum()
+		}
+
+		values := enumDesc.Values()
+		enum := make([]any, 0, values.Len()*2)
+		for i := 0; i < values.Len(); i++ {
+			enum = append(enum, string(values.Get(i).Name()))
+		}
+		for i := 0; i < values.Len(); i++ {
+			enum = append(enum, int32(values.Get(i).Number()))
+		}
+		return map[string]any{"enum": enum}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return g.messageSchema(message)
+	default:
+		return map[string]any{}
+	}
+}
+
+// cloneSchema deeply copies registered schema, so generated one may be changed safely.
+func cloneSchema(schema any) any {
+	switch t := schema.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(t))
+		for k, v := range t {
+			result[k] = cloneSchema(v)
+		}
+		return result
+	case []any:
+		result := make([]any, len(t))
+		for i, v := range t {
+			result[i] = cloneSchema(v)
+		}
+		return result
+	default:
+		return t
+	}
+}
+
+func mapKeySchema(kind protoreflect.Kind) map[string]any {
+	switch kind {
+	case protoreflect.BoolKind:
+		return map[string]any{"enum": []any{"true", "false"}}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return map[string]any{"pattern": "^[0-9]+$"}
+	case protoreflect.StringKind:
+		return nil
+	default:
+		return map[string]any{"pattern": "^-?[0-9]+$"}
+	}
+}
+
+func descriptorComments(desc protoreflect.Descriptor) string {
+	loc := desc.ParentFile().SourceLocations().ByDescriptor(desc)
+	return strings.TrimSpace(loc.LeadingComments)
+}
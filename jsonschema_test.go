@@ -0,0 +1,181 @@
+package protomap_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/gekatateam/protomap"
+	"github.com/gekatateam/protomap/interceptors"
+)
+
+func TestJSONSchema_Message(t *testing.T) {
+	mapper, err := protomap.NewMapper(nil, testProto)
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	schema, err := mapper.JSONSchema(testMessage)
+	if err != nil {
+		t.Fatalf("schema generation failed: %v", err)
+	}
+
+	if _, err := json.Marshal(schema); err != nil {
+		t.Fatalf("schema marshaling failed: %v", err)
+	}
+
+	if schema["$ref"] != "#/$defs/protomap.test.Test" {
+		t.Fatalf("unexpected root reference: %v", schema["$ref"])
+	}
+
+	defs := schema["$defs"].(map[string]any)
+	test := defs["protomap.test.Test"].(map[string]any)
+
+	if expected := []any{"Map", "List", "IntMap"}; !reflect.DeepEqual(expected, test["required"]) {
+		t.Fatalf("expected required %v, got %v", expected, test["required"])
+	}
+
+	props := test["properties"].(map[string]any)
+	if expected := map[string]any{"enum": []any{"OK", "FAILED", int32(0), int32(1)}}; !reflect.DeepEqual(expected, props["Enum"]) {
+		t.Fatalf("expected enum %v, got %v", expected, props["Enum"])
+	}
+
+	expectedMap := map[string]any{
+		"type":                 "object",
+		"additionalProperties": map[string]any{"type": "integer", "minimum": -2147483648, "maximum": 2147483647},
+		"propertyNames":        map[string]any{"pattern": "^-?[0-9]+$"},
+	}
+	if !reflect.DeepEqual(expectedMap, props["IntMap"]) {
+		t.Fatalf("expected map %v, got %v", expectedMap, props["IntMap"])
+	}
+
+	inner := props["Inner"].(map[string]any)["anyOf"].([]any)[0]
+	if !reflect.DeepEqual(map[string]any{"$ref": "#/$defs/protomap.test.Inner"}, inner) {
+		t.Fatalf("unexpected inner reference: %v", inner)
+	}
+
+	if _, ok := defs["protomap.test.Inner"]; !ok {
+		t.Fatal("expected inner message definition")
+	}
+
+	oneOf := test["allOf"].([]any)[0].(map[string]any)["oneOf"].([]any)
+	if len(oneOf) != 3 {
+		t.Fatalf("expected two oneof fields and none variant, got %v", oneOf)
+	}
+}
+
+func TestJSONSchema_EnumMap(t *testing.T) {
+	mapper, err := protomap.NewMapper(nil, "./testdata/maps.proto")
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	schema, err := mapper.JSONSchema("protomap.test.WithEnumMap")
+	if err != nil {
+		t.Fatalf("schema generation failed: %v", err)
+	}
+
+	props := schema["$defs"].(map[string]any)["protomap.test.WithEnumMap"].(map[string]any)["properties"].(map[string]any)
+	expected := map[string]any{"enum": []any{"UNKNOWN", "ACTIVE", "DISABLED", int32(0), int32(1), int32(2)}}
+	if values := props["States"].(map[string]any)["additionalProperties"]; !reflect.DeepEqual(expected, values) {
+		t.Fatalf("expected map values %v, got %v", expected, values)
+	}
+}
+
+func TestJSONSchema_RegisteredWellKnown(t *testing.T) {
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{}),
+	}
+
+	plain, err := protomap.NewMapper(&compiler, testIntersProto)
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	registry := protomap.NewRegistry()
+	interceptors.RegisterWellKnown(registry)
+
+	schema, err := plain.WithRegistry(registry).JSONSchema(testIntersMessage)
+	if err != nil {
+		t.Fatalf("schema generation failed: %v", err)
+	}
+
+	defs := schema["$defs"].(map[string]any)
+	props := defs[testIntersMessage].(map[string]any)["properties"].(map[string]any)
+	ts := props["Ts"].(map[string]any)["anyOf"].([]any)[0]
+
+	if expected := map[string]any{"type": "string", "format": "date-time"}; !reflect.DeepEqual(expected, ts) {
+		t.Fatalf("expected timestamp schema %v, got %v", expected, ts)
+	}
+
+	if _, ok := defs["google.protobuf.Timestamp"]; ok {
+		t.Fatal("expected registered timestamp to be inlined")
+	}
+}
+
+func TestJSONSchema_RegisteredSchemas(t *testing.T) {
+	plain := newWellKnownMapper(t)
+
+	registry := protomap.NewRegistry()
+	interceptors.RegisterWellKnown(registry)
+	mapper := plain.WithRegistry(registry)
+
+	schema, err := mapper.JSONSchema("protomap.test.WithWrappers")
+	if err != nil {
+		t.Fatalf("schema generation failed: %v", err)
+	}
+
+	props := schema["$defs"].(map[string]any)["protomap.test.WithWrappers"].(map[string]any)["properties"].(map[string]any)
+	value := props["Int"].(map[string]any)["anyOf"].([]any)[0]
+	if expected := map[string]any{"type": "integer"}; !reflect.DeepEqual(expected, value) {
+		t.Fatalf("expected wrapper schema %v, got %v", expected, value)
+	}
+
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{}),
+	}
+
+	inters, err := protomap.NewMapper(&compiler, testIntersProto)
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+	mapper = inters.WithRegistry(registry)
+
+	// replaced encoder must not be described by previous schema
+	registry.Register("google.protobuf.Timestamp", interceptors.UnixTimeEncoder(time.Millisecond), interceptors.TimeDecoder)
+	schema, err = mapper.JSONSchema(testIntersMessage)
+	if err != nil {
+		t.Fatalf("schema generation failed: %v", err)
+	}
+
+	props = schema["$defs"].(map[string]any)[testIntersMessage].(map[string]any)["properties"].(map[string]any)
+	if ts := props["Ts"].(map[string]any)["anyOf"].([]any)[0]; !reflect.DeepEqual(map[string]any{}, ts) {
+		t.Fatalf("expected any value schema for re-registered timestamp, got %v", ts)
+	}
+
+	registry.RegisterSchema("google.protobuf.Timestamp", interceptors.UnixTimeSchema(time.Millisecond))
+	schema, err = mapper.JSONSchema(testIntersMessage)
+	if err != nil {
+		t.Fatalf("schema generation failed: %v", err)
+	}
+
+	props = schema["$defs"].(map[string]any)[testIntersMessage].(map[string]any)["properties"].(map[string]any)
+	ts := props["Ts"].(map[string]any)["anyOf"].([]any)[0]
+	if !reflect.DeepEqual(interceptors.UnixTimeSchema(time.Millisecond), ts) {
+		t.Fatalf("expected unix time schema, got %v", ts)
+	}
+
+	// generated schema is a copy of registered one
+	ts.(map[string]any)["anyOf"].([]any)[0].(map[string]any)["type"] = "changed"
+	schema, err = mapper.JSONSchema(testIntersMessage)
+	if err != nil {
+		t.Fatalf("schema generation failed: %v", err)
+	}
+
+	props = schema["$defs"].(map[string]any)[testIntersMessage].(map[string]any)["properties"].(map[string]any)
+	if ts := props["Ts"].(map[string]any)["anyOf"].([]any)[0]; !reflect.DeepEqual(interceptors.UnixTimeSchema(time.Millisecond), ts) {
+		t.Fatalf("expected registered schema to stay unchanged, got %v", ts)
+	}
+}
@@ -0,0 +1,86 @@
+package protomap
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// Merge merges overlay into base following protobuf rules: set scalar fields replace, lists are appended,
+// maps are merged by key and messages are merged recursively. Base and overlay are binaries or maps, that Encode accepts;
+// result has the same form as base. Note that scalar fields without presence are not set, if they have zero values.
+func (m *Mapper) Merge(messageName string, base, overlay any) (any, error) {
+	return m.mergeWith(messageName, base, overlay, func(dst, src *dynamicpb.Message) error {
+		proto.Merge(dst, src)
+		return nil
+	})
+}
+
+// Patch copies fields selected by FieldMask paths, like "Inner.Foo", from overlay to base;
+// selected field is replaced with overlay value, or cleared, if it is not set in overlay.
+// Base and overlay are binaries or maps, that Encode accepts; result has the same form as base.
+func (m *Mapper) Patch(messageName string, base, overlay any, paths ...string) (any, error) {
+	return m.mergeWith(messageName, base, overlay, func(dst, src *dynamicpb.Message) error {
+		for _, path := range paths {
+			if err := patchPath(dst, src, strings.Split(path, ".")); err != nil {
+				return fmt.Errorf("%v: %w", path, err)
+			}
+		}
+		return nil
+	})
+}
+
+func (m *Mapper) mergeWith(messageName string, base, overlay any, merge func(dst, src *dynamicpb.Message) error) (any, error) {
+	desc, err := m.findMessage(messageName)
+	if err != nil {
+		return nil, err
+	}
+
+	dst, err := m.toMessage(desc, base)
+	if err != nil {
+		return nil, fmt.Errorf("base: %w", err)
+	}
+
+	src, err := m.toMessage(desc, overlay)
+	if err != nil {
+		return nil, fmt.Errorf("overlay: %w", err)
+	}
+
+	if err := merge(dst, src); err != nil {
+		return nil, err
+	}
+
+	if _, ok := base.([]byte); ok {
+		return m.marshal(dst)
+	}
+	return m.MessageToAny(dst)
+}
+
+func patchPath(dst, src protoreflect.Message, names []string) error {
+	field := dst.Descriptor().Fields().ByName(protoreflect.Name(names[0]))
+	if field == nil {
+		return fmt.Errorf("no such field %v in message %v", names[0], dst.Descriptor().FullName())
+	}
+
+	if len(names) == 1 {
+		if src.Has(field) {
+			dst.Set(field, src.Get(field))
+		} else {
+			dst.Clear(field)
+		}
+		return nil
+	}
+
+	if field.Message() == nil || field.IsList() || field.IsMap() {
+		return fmt.Errorf("%v is not a singular message field", field.Name())
+	}
+
+	if !src.Has(field) && !dst.Has(field) {
+		return nil
+	}
+
+	return patchPath(dst.Mutable(field).Message(), src.Get(field).Message(), names[1:])
+}
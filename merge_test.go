@@ -0,0 +1,131 @@
+package protomap_test
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/gekatateam/protomap"
+)
+
+func TestMerge_MapsAndBinaries(t *testing.T) {
+	mapper, err := protomap.NewMapper(nil, testProto)
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	base := map[string]any{
+		"String": "base",
+		"Map":    map[string]any{"foo": "foo", "bar": "bar"},
+		"List":   []any{"one"},
+		"Int":    1,
+		"Inner":  map[string]any{"Foo": "fizz", "List": []any{1}},
+		"IntMap": map[string]any{},
+		"Type":   "type",
+	}
+
+	overlay := map[string]any{
+		"Map":    map[string]any{"bar": "baz"},
+		"List":   []any{"two"},
+		"Int":    2,
+		"Inner":  map[string]any{"List": []any{2}},
+		"IntMap": map[string]any{},
+		"Number": 1.5,
+	}
+
+	result, err := mapper.Merge(testMessage, base, overlay)
+	if err != nil {
+		t.Fatalf("merge failed: %v", err)
+	}
+
+	expected := map[string]any{
+		"String": "base",
+		"Map":    map[string]any{"foo": "foo", "bar": "baz"},
+		"Binary": []byte(nil),
+		"List":   []any{"one", "two"},
+		"Int":    int64(2),
+		"Uint":   uint64(0),
+		"Float":  float64(0),
+		"Inner":  map[string]any{"Foo": "fizz", "List": []any{int64(1), int64(2)}},
+		"IntMap": map[string]any{},
+		"Number": 1.5,
+		"Enum":   "OK",
+	}
+
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	binary, err := os.ReadFile(testBinary)
+	if err != nil {
+		t.Fatalf("binary data reading failed: %v", err)
+	}
+
+	merged, err := mapper.Merge(testMessage, binary, overlay)
+	if err != nil {
+		t.Fatalf("merge failed: %v", err)
+	}
+
+	decoded, err := mapper.Decode(merged.([]byte), testMessage)
+	if err != nil {
+		t.Fatalf("merged binary decoding failed: %v", err)
+	}
+
+	if list := decoded.(map[string]any)["List"]; !reflect.DeepEqual([]any{"tag one", "tag two", "two"}, list) {
+		t.Fatalf("expected appended list, got %v", list)
+	}
+}
+
+func TestMerge_Patch(t *testing.T) {
+	mapper, err := protomap.NewMapper(nil, testProto)
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	base := map[string]any{
+		"String": "base",
+		"Map":    map[string]any{"foo": "foo"},
+		"List":   []any{"one"},
+		"Inner":  map[string]any{"Foo": "fizz", "List": []any{1}},
+		"IntMap": map[string]any{},
+	}
+
+	overlay := map[string]any{
+		"String": "overlay",
+		"Map":    map[string]any{"bar": "bar"},
+		"List":   []any{"two"},
+		"Inner":  map[string]any{"List": []any{2}},
+		"IntMap": map[string]any{},
+	}
+
+	result, err := mapper.Patch(testMessage, base, overlay, "List", "Inner.Foo", "Map")
+	if err != nil {
+		t.Fatalf("patch failed: %v", err)
+	}
+
+	data := result.(map[string]any)
+	if data["String"] != "base" {
+		t.Fatalf("expected unmasked field to stay, got %v", data["String"])
+	}
+
+	if !reflect.DeepEqual([]any{"two"}, data["List"]) {
+		t.Fatalf("expected replaced list, got %v", data["List"])
+	}
+
+	if !reflect.DeepEqual(map[string]any{"bar": "bar"}, data["Map"]) {
+		t.Fatalf("expected replaced map, got %v", data["Map"])
+	}
+
+	expectedInner := map[string]any{"Foo": "", "List": []any{int64(1)}}
+	if !reflect.DeepEqual(expectedInner, data["Inner"]) {
+		t.Fatalf("expected %v, got %v", expectedInner, data["Inner"])
+	}
+
+	if _, err := mapper.Patch(testMessage, base, overlay, "List.Foo"); err == nil {
+		t.Fatal("expected error for path through list")
+	}
+
+	if _, err := mapper.Patch(testMessage, base, overlay, "Unknown"); err == nil {
+		t.Fatal("expected error for unknown field")
+	}
+}
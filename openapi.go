@@ -0,0 +1,276 @@
+package protomap
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	openAPIVersion = "3.1.0"
+	httpRuleOption = "google.api.http"
+)
+
+var pathParamRegexp = regexp.MustCompile(`\{([^}=]+)(=[^}]*)?\}`)
+
+type httpBinding struct {
+	method       string
+	path         string
+	body         string
+	responseBody string
+}
+
+// OpenAPI returns OpenAPI 3.1 document describing services, or all services of Mapper files, if not set.
+// Methods are bound to paths by google.api.http annotations; methods without annotation
+// are bound to "POST /package.Service/Method" with request message body, except streaming ones, which are skipped.
+// Messages are described in components like JSONSchema does.
+func (m *Mapper) OpenAPI(title, version string, services ...string) (map[string]any, error) {
+	descs, err := m.findServices(services...)
+	if err != nil {
+		return nil, err
+	}
+
+	g := newSchemaGenerator(m, "#/components/schemas/")
+	paths := make(map[string]any)
+
+	for _, service := range descs {
+		methods := service.Methods()
+		for i := 0; i < methods.Len(); i++ {
+			method := methods.Get(i)
+
+			bindings, err := methodBindings(method)
+			if err != nil {
+				return nil, err
+			}
+
+			for j, binding := range bindings {
+				op, err := g.operation(method, binding)
+				if err != nil {
+					return nil, err
+				}
+
+				op["operationId"] = fmt.Sprintf("%v_%v", service.Name(), method.Name())
+				if j > 0 {
+					op["operationId"] = fmt.Sprintf("%v_%v_%v", service.Name(), method.Name(), j)
+				}
+
+				path := pathParamRegexp.ReplaceAllString(binding.path, "{$1}")
+				item, ok := paths[path].(map[string]any)
+				if !ok {
+					item = make(map[string]any)
+					paths[path] = item
+				}
+				item[binding.method] = op
+			}
+		}
+	}
+
+	return map[string]any{
+		"openapi": openAPIVersion,
+		"info": map[string]any{
+			"title":   title,
+			"version": version,
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": g.defs,
+		},
+	}, nil
+}
+
+func (m *Mapper) findServices(services ...string) ([]protoreflect.ServiceDescriptor, error) {
+	var result []protoreflect.ServiceDescriptor
+
+	if len(services) == 0 {
+		for _, file := range m.files {
+			for i := 0; i < file.Services().Len(); i++ {
+				result = append(result, file.Services().Get(i))
+			}
+		}
+		return result, nil
+	}
+
+	for _, name := range services {
+		service, err := m.FindService(name)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, service)
+	}
+
+	return result, nil
+}
+
+func methodBindings(method protoreflect.MethodDescriptor) ([]httpBinding, error) {
+	rule, ok := findRules(method, httpRuleOption)
+	if !ok {
+		if method.IsStreamingClient() || method.IsStreamingServer() {
+			return nil, nil
+		}
+
+		return []httpBinding{{
+			method: "post",
+			path:   fmt.Sprintf("/%v/%v", method.Parent().FullName(), method.Name()),
+			body:   "*",
+		}}, nil
+	}
+
+	binding, err := parseHttpRule(rule)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", method.FullName(), err)
+	}
+	bindings := []httpBinding{binding}
+
+	if additional, ok := ruleValue(rule, "additional_bindings"); ok {
+		list := additional.List()
+		for i := 0; i < list.Len(); i++ {
+			binding, err := parseHttpRule(list.Get(i).Message())
+			if err != nil {
+				return nil, fmt.Errorf("%v: %w", method.FullName(), err)
+			}
+			bindings = append(bindings, binding)
+		}
+	}
+
+	return bindings, nil
+}
+
+func parseHttpRule(rule protoreflect.Message) (httpBinding, error) {
+	var binding httpBinding
+
+	pattern := rule.WhichOneof(rule.Descriptor().Oneofs().ByName("pattern"))
+	switch {
+	case pattern == nil:
+		return binding, fmt.Errorf("http rule has no pattern")
+	case pattern.Name() == "custom":
+		custom := rule.Get(pattern).Message()
+		kind, _ := ruleValue(custom, "kind")
+		path, _ := ruleValue(custom, "path")
+		binding.method, binding.path = strings.ToLower(kind.String()), path.String()
+	default:
+		binding.method, binding.path = string(pattern.Name()), rule.Get(pattern).String()
+	}
+
+	if v, ok := ruleValue(rule, "body"); ok {
+		binding.body = v.String()
+	}
+	if v, ok := ruleValue(rule, "response_body"); ok {
+		binding.responseBody = v.String()
+	}
+
+	return binding, nil
+}
+
+func (g *schemaGenerator) operation(method protoreflect.MethodDescriptor, binding httpBinding) (map[string]any, error) {
+	input, output := method.Input(), method.Output()
+	parameters := []any{}
+	bound := make(map[string]bool)
+
+	for _, match := range pathParamRegexp.FindAllStringSubmatch(binding.path, -1) {
+		name := match[1]
+		field := fieldByPath(input, name)
+		if field == nil {
+			return nil, fmt.Errorf("%v: path parameter %q not found in %v", method.FullName(), name, input.FullName())
+		}
+
+		bound[strings.SplitN(name, ".", 2)[0]] = true
+		parameters = append(parameters, map[string]any{
+			"name":     name,
+			"in":       "path",
+			"required": true,
+			"schema":   g.valueSchema(field, field.Kind(), field.Message()),
+		})
+	}
+
+	op := map[string]any{
+		"tags": []any{string(method.Parent().Name())},
+	}
+
+	if description := descriptorComments(method); description != "" {
+		op["description"] = description
+	}
+
+	if binding.body != "" {
+		body, err := g.bodySchema(input, binding.body)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", method.FullName(), err)
+		}
+
+		op["requestBody"] = map[string]any{
+			"required": true,
+			"content":  map[string]any{"application/json": map[string]any{"schema": body}},
+		}
+	}
+
+	// the rest of scalar fields are query parameters, unless the whole message is a body
+	if binding.body != "*" {
+		fields := input.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			field := fields.Get(i)
+			name := string(field.Name())
+			if bound[name] || name == binding.body || field.Message() != nil || g.options.get(field).skip {
+				continue
+			}
+
+			parameters = append(parameters, map[string]any{
+				"name":   name,
+				"in":     "query",
+				"schema": g.fieldSchema(field),
+			})
+		}
+	}
+
+	if len(parameters) > 0 {
+		op["parameters"] = parameters
+	}
+
+	response, err := g.bodySchema(output, binding.responseBody)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", method.FullName(), err)
+	}
+
+	op["responses"] = map[string]any{
+		"200": map[string]any{
+			"description": "OK",
+			"content":     map[string]any{"application/json": map[string]any{"schema": response}},
+		},
+	}
+
+	return op, nil
+}
+
+// bodySchema returns schema of whole message for "*" or empty body, or schema of message field otherwise.
+func (g *schemaGenerator) bodySchema(desc protoreflect.MessageDescriptor, body string) (map[string]any, error) {
+	if body == "" || body == "*" {
+		return g.messageSchema(desc), nil
+	}
+
+	field := desc.Fields().ByName(protoreflect.Name(body))
+	if field == nil {
+		return nil, fmt.Errorf("body field %q not found in %v", body, desc.FullName())
+	}
+
+	if field.Message() != nil && !field.IsList() && !field.IsMap() {
+		return g.messageSchema(field.Message()), nil
+	}
+	return g.fieldSchema(field), nil
+}
+
+// fieldByPath finds field by dot-separated path of field names, like "book.name".
+func fieldByPath(desc protoreflect.MessageDescriptor, path string) protoreflect.FieldDescriptor {
+	var field protoreflect.FieldDescriptor
+	for _, name := range strings.Split(path, ".") {
+		if desc == nil {
+			return nil
+		}
+
+		field = desc.Fields().ByName(protoreflect.Name(name))
+		if field == nil {
+			return nil
+		}
+		desc = field.Message()
+	}
+	return field
+}
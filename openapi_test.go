@@ -0,0 +1,96 @@
+package protomap_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/gekatateam/protomap"
+)
+
+func TestOpenAPI_Service(t *testing.T) {
+	compiler := protocompile.Compiler{
+		Resolver:       protocompile.WithStandardImports(&protocompile.SourceResolver{ImportPaths: []string{"./testdata"}}),
+		SourceInfoMode: protocompile.SourceInfoStandard,
+	}
+
+	mapper, err := protomap.NewMapper(&compiler, "openapi.proto")
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	doc, err := mapper.OpenAPI("Library", "1.0.0")
+	if err != nil {
+		t.Fatalf("document generation failed: %v", err)
+	}
+
+	if _, err := json.Marshal(doc); err != nil {
+		t.Fatalf("document marshaling failed: %v", err)
+	}
+
+	paths := doc["paths"].(map[string]any)
+
+	var got []string
+	for path, item := range paths {
+		for method := range item.(map[string]any) {
+			got = append(got, method+" "+path)
+		}
+	}
+
+	expected := map[string]bool{
+		"get /v1/{name}":                   true,
+		"post /v1/{parent}/books":          true,
+		"patch /v1/{book.name}":            true,
+		"put /v1/{book.name}":              true,
+		"get /v1/{name}:title":             true,
+		"post /protomap.test.Library/Ping": true,
+	}
+
+	if len(got) != len(expected) {
+		t.Fatalf("expected operations %v, got %v", expected, got)
+	}
+	for _, op := range got {
+		if !expected[op] {
+			t.Fatalf("unexpected operation %v", op)
+		}
+	}
+
+	get := paths["/v1/{name}"].(map[string]any)["get"].(map[string]any)
+	if get["operationId"] != "Library_GetBook" || get["description"] != "GetBook returns book by name." {
+		t.Fatalf("unexpected get operation: %v", get)
+	}
+
+	var params []string
+	for _, p := range get["parameters"].([]any) {
+		params = append(params, p.(map[string]any)["in"].(string)+" "+p.(map[string]any)["name"].(string))
+	}
+
+	if expected := []string{"path name", "query view", "query fields"}; !reflect.DeepEqual(expected, params) {
+		t.Fatalf("expected parameters %v, got %v", expected, params)
+	}
+
+	create := paths["/v1/{parent}/books"].(map[string]any)["post"].(map[string]any)
+	body := create["requestBody"].(map[string]any)["content"].(map[string]any)["application/json"].(map[string]any)["schema"]
+	if !reflect.DeepEqual(map[string]any{"$ref": "#/components/schemas/protomap.test.Book"}, body) {
+		t.Fatalf("unexpected create body: %v", body)
+	}
+
+	title := paths["/v1/{name}:title"].(map[string]any)["get"].(map[string]any)
+	response := title["responses"].(map[string]any)["200"].(map[string]any)["content"].(map[string]any)["application/json"].(map[string]any)["schema"]
+	if !reflect.DeepEqual(map[string]any{"type": "string"}, response) {
+		t.Fatalf("unexpected title response: %v", response)
+	}
+
+	schemas := doc["components"].(map[string]any)["schemas"].(map[string]any)
+	for _, name := range []string{"protomap.test.Book", "protomap.test.UpdateBookRequest"} {
+		if _, ok := schemas[name]; !ok {
+			t.Fatalf("expected %v in components", name)
+		}
+	}
+
+	if _, err := mapper.OpenAPI("Library", "1.0.0", "protomap.test.Unknown"); !errors.Is(err, protomap.ErrNoSuchService) {
+		t.Fatalf("expected ErrNoSuchService, got %v", err)
+	}
+}
@@ -0,0 +1,133 @@
+package protomap
+
+import (
+	_ "embed"
+	"sync"
+
+	"github.com/bufbuild/protocompile"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// OptionsFile is an import path of bundled protomap options, see proto/protomap/options.proto.
+const OptionsFile = "protomap/options.proto"
+
+const (
+	skipOption      = "protomap.skip"
+	formatOption    = "protomap.format"
+	defaultOption   = "protomap.default"
+	omitEmptyOption = "protomap.omit_empty"
+)
+
+//go:embed proto/protomap/options.proto
+var optionsProto string
+
+// WithOptionsImport returns resolver that also resolves bundled "protomap/options.proto".
+// It imports "google/protobuf/descriptor.proto", so standard imports must be resolvable too,
+// e.g. with protocompile.WithStandardImports.
+func WithOptionsImport(resolver protocompile.Resolver) protocompile.Resolver {
+	return protocompile.CompositeResolver{
+		resolver,
+		&protocompile.SourceResolver{
+			Accessor: protocompile.SourceAccessorFromMap(map[string]string{OptionsFile: optionsProto}),
+		},
+	}
+}
+
+// FindOption returns value of custom option set on descriptor, e.g. field or message,
+// by option full name, like "my.package.format".
+func FindOption(desc protoreflect.Descriptor, optionName string) (protoreflect.Value, bool) {
+	opts := desc.Options()
+	if opts == nil {
+		return protoreflect.Value{}, false
+	}
+
+	var result protoreflect.Value
+	var found bool
+	opts.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.IsExtension() && fd.FullName() == protoreflect.FullName(optionName) {
+			result, found = v, true
+			return false
+		}
+		return true
+	})
+
+	return result, found
+}
+
+// IsSkipped reports whether field has (protomap.skip) option set.
+func (m *Mapper) IsSkipped(field protoreflect.FieldDescriptor) bool {
+	return m.options.get(field).skip
+}
+
+// FieldFormat returns value of (protomap.format) option of field, if set.
+func (m *Mapper) FieldFormat(field protoreflect.FieldDescriptor) (string, bool) {
+	opts := m.options.get(field)
+	return opts.format, opts.hasFormat
+}
+
+// descOptions holds protomap options of field or message.
+type descOptions struct {
+	skip       bool
+	omitEmpty  bool
+	format     string
+	hasFormat  bool
+	def        string
+	hasDefault bool
+}
+
+var noOptions = &descOptions{}
+
+// optionsCache keeps options parsed once per descriptor full name;
+// nil cache parses them on every call, which is used by package level functions.
+type optionsCache struct {
+	parsed sync.Map
+}
+
+func (c *optionsCache) get(desc protoreflect.Descriptor) *descOptions {
+	if c == nil {
+		return parseOptions(desc)
+	}
+
+	if opts, ok := c.parsed.Load(desc.FullName()); ok {
+		return opts.(*descOptions)
+	}
+
+	opts, _ := c.parsed.LoadOrStore(desc.FullName(), parseOptions(desc))
+	return opts.(*descOptions)
+}
+
+func parseOptions(desc protoreflect.Descriptor) *descOptions {
+	opts := desc.Options()
+	if opts == nil {
+		return noOptions
+	}
+
+	result := descOptions{}
+	found := false
+	opts.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if !fd.IsExtension() {
+			return true
+		}
+
+		switch fd.FullName() {
+		case skipOption:
+			result.skip, _ = v.Interface().(bool)
+		case omitEmptyOption:
+			result.omitEmpty, _ = v.Interface().(bool)
+		case formatOption:
+			result.format, result.hasFormat = v.Interface().(string)
+		case defaultOption:
+			result.def, result.hasDefault = v.Interface().(string)
+		default:
+			return true
+		}
+
+		found = true
+		return true
+	})
+
+	if !found {
+		return noOptions
+	}
+	return &result
+}
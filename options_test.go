@@ -0,0 +1,169 @@
+package protomap_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/gekatateam/protomap"
+)
+
+func newOptionsMapper(t *testing.T) *protomap.Mapper {
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(protomap.WithOptionsImport(&protocompile.SourceResolver{})),
+	}
+
+	mapper, err := protomap.NewMapper(&compiler, "./testdata/options.proto")
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+	return mapper
+}
+
+func TestOptions_EncodeThenDecode(t *testing.T) {
+	mapper := newOptionsMapper(t)
+
+	created := time.Date(2025, 1, 2, 3, 4, 5, 6e6, time.UTC)
+	input := map[string]any{
+		"Id":        "123e4567-e89b-12d3-a456-426614174000",
+		"Hash":      "deadbeef",
+		"CreatedMs": created,
+		"Refs":      []any{"00000000-0000-0000-0000-000000000001"},
+		"Internal":  "must be ignored",
+		"Extra": map[string]any{
+			"Name": "first",
+			"Next": map[string]any{"Tags": []any{"a"}},
+		},
+	}
+
+	binary, err := mapper.Encode(input, "protomap.test.WithOptions")
+	if err != nil {
+		t.Fatalf("map input encoding failed: %v", err)
+	}
+
+	result, err := mapper.Decode(binary, "protomap.test.WithOptions")
+	if err != nil {
+		t.Fatalf("binary data decoding failed: %v", err)
+	}
+
+	expected := map[string]any{
+		"Id":        "123e4567-e89b-12d3-a456-426614174000",
+		"Hash":      "deadbeef",
+		"CreatedMs": created,
+		"Refs":      []any{"00000000-0000-0000-0000-000000000001"},
+		"Retries":   int64(3),
+		"Level":     "LEVEL_INFO",
+		"Extra": map[string]any{
+			"Name": "first",
+			"Next": map[string]any{"Tags": []any{"a"}},
+		},
+	}
+
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestOptions_EmptyUUID(t *testing.T) {
+	mapper := newOptionsMapper(t)
+
+	binary, err := mapper.Encode(map[string]any{"Refs": []any{}}, "protomap.test.WithOptions")
+	if err != nil {
+		t.Fatalf("map input encoding failed: %v", err)
+	}
+
+	result, err := mapper.Decode(binary, "protomap.test.WithOptions")
+	if err != nil {
+		t.Fatalf("binary data decoding failed: %v", err)
+	}
+
+	data := result.(map[string]any)
+	if data["Id"] != "" {
+		t.Fatalf("expected empty uuid for unset field, got %v", data["Id"])
+	}
+
+	again, err := mapper.Encode(data, "protomap.test.WithOptions")
+	if err != nil {
+		t.Fatalf("decoded data encoding failed: %v", err)
+	}
+
+	result, err = mapper.Decode(again, "protomap.test.WithOptions")
+	if err != nil {
+		t.Fatalf("binary data decoding failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(data, result) {
+		t.Fatalf("expected %v, got %v", data, result)
+	}
+}
+
+func TestOptions_EmptyUUIDSchema(t *testing.T) {
+	schema, err := newOptionsMapper(t).JSONSchema("protomap.test.WithOptions")
+	if err != nil {
+		t.Fatalf("schema generation failed: %v", err)
+	}
+
+	props := schema["$defs"].(map[string]any)["protomap.test.WithOptions"].(map[string]any)["properties"].(map[string]any)
+	expected := map[string]any{"type": "string", "anyOf": []any{
+		map[string]any{"format": "uuid"},
+		map[string]any{"maxLength": 0},
+	}}
+
+	if !reflect.DeepEqual(expected, props["Id"]) {
+		t.Fatalf("expected %v, got %v", expected, props["Id"])
+	}
+}
+
+func TestOptions_Errors(t *testing.T) {
+	mapper := newOptionsMapper(t)
+
+	for _, invalid := range []map[string]any{
+		{"Id": "not-a-uuid", "Refs": []any{}},
+		{"Hash": "xyz", "Refs": []any{}},
+		{"Refs": []any{"123"}},
+		{"Retries": "many", "Refs": []any{}},
+	} {
+		if _, err := mapper.Encode(invalid, "protomap.test.WithOptions"); err == nil {
+			t.Fatalf("expected error for %v", invalid)
+		}
+	}
+
+	binary, err := mapper.Encode(map[string]any{"Id": []byte{1, 2, 3}, "Refs": []any{}}, "protomap.test.WithOptions")
+	if err != nil {
+		t.Fatalf("map input encoding failed: %v", err)
+	}
+
+	if _, err := mapper.Decode(binary, "protomap.test.WithOptions"); err == nil {
+		t.Fatal("expected error for 3 bytes decoded as uuid")
+	}
+}
+
+func BenchmarkOptions_Decode(b *testing.B) {
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(protomap.WithOptionsImport(&protocompile.SourceResolver{})),
+	}
+
+	mapper, err := protomap.NewMapper(&compiler, "./testdata/options.proto")
+	if err != nil {
+		b.Fatalf("mapper creation failed: %v", err)
+	}
+
+	binary, err := mapper.Encode(map[string]any{
+		"Id":        "123e4567-e89b-12d3-a456-426614174000",
+		"Hash":      "deadbeef",
+		"CreatedMs": time.Now(),
+		"Refs":      []any{"00000000-0000-0000-0000-000000000001"},
+		"Extra":     map[string]any{"Name": "first", "Tags": []any{"a", "b"}},
+	}, "protomap.test.WithOptions")
+	if err != nil {
+		b.Fatalf("map input encoding failed: %v", err)
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := mapper.Decode(binary, "protomap.test.WithOptions"); err != nil {
+			b.Fatalf("binary data decoding failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,363 @@
+package protomap
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var ErrInvalidPath = errors.New("invalid path")
+
+// pathStep is a field name, optionally followed by list index or map key selector.
+type pathStep struct {
+	name     string
+	selector string
+	selected bool
+}
+
+// parsePath parses paths like "Inner.List[1]", "Map['foo']" or "Map[foo].Field".
+func parsePath(path string) ([]pathStep, error) {
+	var steps []pathStep
+
+	for rest := path; ; {
+		end := strings.IndexAny(rest, ".[")
+		if end < 0 {
+			end = len(rest)
+		}
+
+		step := pathStep{name: rest[:end]}
+		if step.name == "" {
+			return nil, fmt.Errorf("%w: %q: empty field name", ErrInvalidPath, path)
+		}
+		rest = rest[end:]
+
+		if strings.HasPrefix(rest, "[") {
+			var selector string
+			switch {
+			case strings.HasPrefix(rest, "['"), strings.HasPrefix(rest, `["`):
+				quote := rest[1:2]
+				closing := strings.Index(rest[2:], quote+"]")
+				if closing < 0 {
+					return nil, fmt.Errorf("%w: %q: unclosed key", ErrInvalidPath, path)
+				}
+				selector, rest = rest[2:2+closing], rest[2+closing+2:]
+			default:
+				closing := strings.Index(rest, "]")
+				if closing < 0 {
+					return nil, fmt.Errorf("%w: %q: unclosed selector", ErrInvalidPath, path)
+				}
+				selector, rest = rest[1:closing], rest[closing+1:]
+			}
+			step.selector, step.selected = selector, true
+		}
+
+		steps = append(steps, step)
+
+		if rest == "" {
+			return steps, nil
+		}
+
+		if rest[0] != '.' {
+			return nil, fmt.Errorf("%w: %q: unexpected %q", ErrInvalidPath, path, rest)
+		}
+		rest = rest[1:]
+	}
+}
+
+// pathTarget is a field of message, or, if selected, its list element or map entry.
+type pathTarget struct {
+	message  protoreflect.Message
+	field    protoreflect.FieldDescriptor
+	path     string
+	selected bool
+	index    int
+	key      protoreflect.MapKey
+}
+
+// resolvePath walks message to the last path step; intermediate messages are created, if mutable is set,
+// otherwise nil target is returned for absent ones.
+func resolvePath(message protoreflect.Message, path string, mutable bool) (*pathTarget, error) {
+	steps, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldPath := ""
+	for i, step := range steps {
+		field := message.Descriptor().Fields().ByName(protoreflect.Name(step.name))
+		if field == nil {
+			return nil, fmt.Errorf("%w: %v: no such field in message %v", ErrInvalidPath, step.name, message.Descriptor().FullName())
+		}
+		fieldPath = joinPath(fieldPath, field.Name())
+
+		target := &pathTarget{message: message, field: field, path: fieldPath, selected: step.selected}
+		switch {
+		case step.selected && field.IsList():
+			target.index, err = strconv.Atoi(step.selector)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v: list index %q is not a number", ErrInvalidPath, step.name, step.selector)
+			}
+		case step.selected && field.IsMap():
+			key, err := (encoder{}).kindToProto(field, field.MapKey().Kind(), step.selector, fieldPath)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v: map key %q: %v", ErrInvalidPath, step.name, step.selector, err)
+			}
+			target.key = key.MapKey()
+		case step.selected:
+			return nil, fmt.Errorf("%w: %v is not a list or map", ErrInvalidPath, step.name)
+		}
+
+		if i == len(steps)-1 {
+			return target, nil
+		}
+
+		// intermediate step must point to a message
+		next, err := target.nextMessage(mutable)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", step.name, err)
+		}
+
+		if next == nil {
+			return nil, nil
+		}
+		message = next
+	}
+
+	return nil, fmt.Errorf("%w: %q: empty path", ErrInvalidPath, path)
+}
+
+func (t *pathTarget) kind() protoreflect.Kind {
+	if t.field.IsMap() {
+		return t.field.MapValue().Kind()
+	}
+	return t.field.Kind()
+}
+
+func (t *pathTarget) nextMessage(mutable bool) (protoreflect.Message, error) {
+	isMessage := t.kind() == protoreflect.MessageKind || t.kind() == protoreflect.GroupKind
+	if !isMessage || (t.field.IsList() || t.field.IsMap()) && !t.selected {
+		return nil, fmt.Errorf("%w: %v is not a message", ErrInvalidPath, t.field.Name())
+	}
+
+	switch {
+	case t.field.IsList():
+		list := t.message.Get(t.field).List()
+		if t.index < 0 || t.index >= list.Len() {
+			return nil, fmt.Errorf("%w: index %v is out of range of %v elements", ErrInvalidPath, t.index, list.Len())
+		}
+		return list.Get(t.index).Message(), nil
+	case t.field.IsMap():
+		if mutable {
+			return t.message.Mutable(t.field).Map().Mutable(t.key).Message(), nil
+		}
+		if !t.message.Get(t.field).Map().Has(t.key) {
+			return nil, nil
+		}
+		return t.message.Get(t.field).Map().Get(t.key).Message(), nil
+	default:
+		if mutable {
+			return t.message.Mutable(t.field).Message(), nil
+		}
+		if !t.message.Has(t.field) {
+			return nil, nil
+		}
+		return t.message.Get(t.field).Message(), nil
+	}
+}
+
+// Get returns value by path, like "Inner.List[1]" or "Map['foo']", from binary or map data of message.
+// Absent messages, fields with presence, including non-set oneof members, and map entries are returned as nil;
+// list index out of range is an error.
+func (m *Mapper) Get(messageName string, data any, path string) (any, error) {
+	desc, err := m.findMessage(messageName)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := m.toMessage(desc, data)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := resolvePath(message, path, false)
+	if err != nil || target == nil {
+		return nil, err
+	}
+
+	d := decoder{inters: m.DecodeInterceptors(), options: m.options}
+	if m.registry != nil {
+		d.fields = m.registry.fields
+	}
+
+	field := target.field
+	switch {
+	case field.IsList() && target.selected:
+		list := target.message.Get(field).List()
+		if target.index < 0 || target.index >= list.Len() {
+			return nil, fmt.Errorf("%w: %v: index %v is out of range of %v elements", ErrInvalidPath, field.Name(), target.index, list.Len())
+		}
+		return d.protoToGoValue(field, field.Kind(), list.Get(target.index), target.path)
+	case field.IsMap() && target.selected:
+		pmap := target.message.Get(field).Map()
+		if !pmap.Has(target.key) {
+			return nil, nil
+		}
+		return d.protoToGoValue(field, field.MapValue().Kind(), pmap.Get(target.key), target.path)
+	case field.IsList():
+		list := target.message.Get(field).List()
+		slice := make([]any, 0, list.Len())
+		for i := 0; i < list.Len(); i++ {
+			value, err := d.protoToGoValue(field, field.Kind(), list.Get(i), target.path)
+			if err != nil {
+				return nil, err
+			}
+			slice = append(slice, value)
+		}
+		return slice, nil
+	case field.IsMap():
+		pmap := target.message.Get(field).Map()
+		gomap := make(map[string]any, pmap.Len())
+		var err error
+		pmap.Range(func(mk protoreflect.MapKey, v protoreflect.Value) bool {
+			gomap[mk.String()], err = d.protoToGoValue(field, field.MapValue().Kind(), v, target.path)
+			return err == nil
+		})
+		return gomap, err
+	case field.HasPresence() && !target.message.Has(field):
+		return nil, nil
+	default:
+		return d.protoToGoValue(field, field.Kind(), target.message.Get(field), target.path)
+	}
+}
+
+// Set sets value by path in binary or map data of message and returns data in the same form.
+// Value is converted like Encode does; intermediate messages are created, setting oneof member clears other ones.
+// List index equal to list length appends value; nil value of message field clears it.
+// Mapper with constraints checks resulting message, not the data passed in.
+func (m *Mapper) Set(messageName string, data any, path string, value any) (any, error) {
+	return m.modifyPath(messageName, data, path, true, func(target *pathTarget) error {
+		e := encoder{inters: m.EncodeInterceptors()}
+		if m.registry != nil {
+			e.fields = m.registry.fields
+		}
+
+		field := target.field
+		switch {
+		case field.IsList() && target.selected:
+			list := target.message.Mutable(field).List()
+			if target.index < 0 || target.index > list.Len() {
+				return fmt.Errorf("%w: %v: index %v is out of range of %v elements", ErrInvalidPath, field.Name(), target.index, list.Len())
+			}
+
+			protovalue, err := e.goValueToProto(field, field.Kind(), value, target.path)
+			if err != nil {
+				return err
+			}
+
+			if target.index == list.Len() {
+				list.Append(protovalue)
+			} else {
+				list.Set(target.index, protovalue)
+			}
+			return nil
+		case field.IsMap() && target.selected:
+			protovalue, err := e.goValueToProto(field, field.MapValue().Kind(), value, target.path)
+			if err != nil {
+				return err
+			}
+			target.message.Mutable(field).Map().Set(target.key, protovalue)
+			return nil
+		case field.IsList():
+			protovalue := target.message.NewField(field)
+			if err := e.fillList(field, value, protovalue.List(), target.path); err != nil {
+				return err
+			}
+			target.message.Set(field, protovalue)
+			return nil
+		case field.IsMap():
+			protovalue := target.message.NewField(field)
+			if err := e.fillMap(field, value, protovalue.Map(), target.path); err != nil {
+				return err
+			}
+			target.message.Set(field, protovalue)
+			return nil
+		case value == nil && field.Message() != nil:
+			target.message.Clear(field)
+			return nil
+		default:
+			protovalue, err := e.goValueToProto(field, field.Kind(), value, target.path)
+			if err != nil {
+				return err
+			}
+			target.message.Set(field, protovalue)
+			return nil
+		}
+	})
+}
+
+// Delete clears field, removes list element or map entry by path in binary or map data of message
+// and returns data in the same form; resulting message is checked like Set does.
+func (m *Mapper) Delete(messageName string, data any, path string) (any, error) {
+	return m.modifyPath(messageName, data, path, false, func(target *pathTarget) error {
+		field := target.field
+		switch {
+		case field.IsList() && target.selected:
+			list := target.message.Mutable(field).List()
+			if target.index < 0 || target.index >= list.Len() {
+				return fmt.Errorf("%w: %v: index %v is out of range of %v elements", ErrInvalidPath, field.Name(), target.index, list.Len())
+			}
+
+			for i := target.index; i < list.Len()-1; i++ {
+				list.Set(i, list.Get(i+1))
+			}
+			list.Truncate(list.Len() - 1)
+		case field.IsMap() && target.selected:
+			target.message.Mutable(field).Map().Clear(target.key)
+		default:
+			target.message.Clear(field)
+		}
+		return nil
+	})
+}
+
+func (m *Mapper) modifyPath(messageName string, data any, path string, mutable bool, modify func(target *pathTarget) error) (any, error) {
+	desc, err := m.findMessage(messageName)
+	if err != nil {
+		return nil, err
+	}
+
+	// constraints are checked once, on modified message, so invalid data may be fixed by path
+	unchecked := *m
+	unchecked.constraints = false
+
+	message, err := unchecked.toMessage(desc, data)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := resolvePath(message, path, mutable)
+	if err != nil {
+		return nil, err
+	}
+
+	// nothing to delete in absent message
+	if target != nil {
+		if err := modify(target); err != nil {
+			return nil, fmt.Errorf("%v: %w", path, err)
+		}
+	}
+
+	if m.constraints {
+		if err := CheckConstraints(message); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, ok := data.([]byte); ok {
+		return m.marshal(message)
+	}
+	return unchecked.MessageToAny(message)
+}
@@ -0,0 +1,180 @@
+package protomap_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/gekatateam/protomap"
+)
+
+const testPathMessage = "protomap.test.WithPaths"
+
+func newPathMapper(t *testing.T) *protomap.Mapper {
+	mapper, err := protomap.NewMapper(nil, "./testdata/path.proto")
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+	return mapper
+}
+
+func newPathData() map[string]any {
+	return map[string]any{
+		"Items": []any{
+			map[string]any{"Name": "first", "Tags": []any{"a"}},
+			map[string]any{"Name": "second", "Tags": []any{}},
+		},
+		"ByName": map[string]any{
+			"foo": map[string]any{"Name": "foo", "Tags": []any{"x", "y"}},
+		},
+		"Text":    "text",
+		"Numbers": []any{1, 2, 3},
+		"ById":    map[string]any{"7": "seven"},
+	}
+}
+
+func TestPath_Get(t *testing.T) {
+	mapper := newPathMapper(t)
+
+	binary, err := mapper.Encode(newPathData(), testPathMessage)
+	if err != nil {
+		t.Fatalf("map input encoding failed: %v", err)
+	}
+
+	for path, expected := range map[string]any{
+		"Items[1].Name":         "second",
+		"ByName['foo'].Tags[1]": "y",
+		`ByName["bar"]`:         nil,
+		"ById[7]":               "seven",
+		"Numbers":               []any{int64(1), int64(2), int64(3)},
+		"Text":                  "text",
+		"Number":                nil,
+		"Main":                  nil,
+		"Main.Name":             nil,
+	} {
+		for _, data := range []any{newPathData(), binary} {
+			result, err := mapper.Get(testPathMessage, data, path)
+			if err != nil {
+				t.Fatalf("%v: get failed: %v", path, err)
+			}
+
+			if !reflect.DeepEqual(expected, result) {
+				t.Fatalf("%v: expected %v, got %v", path, expected, result)
+			}
+		}
+	}
+
+	for _, path := range []string{"Items[2].Name", "Unknown", "Text.Name", "Items.Name", "Numbers[x]", "Items[0", ""} {
+		if _, err := mapper.Get(testPathMessage, newPathData(), path); !errors.Is(err, protomap.ErrInvalidPath) {
+			t.Fatalf("%v: expected ErrInvalidPath, got %v", path, err)
+		}
+	}
+}
+
+func TestPath_SetAndDelete(t *testing.T) {
+	mapper := newPathMapper(t)
+
+	data, err := mapper.Set(testPathMessage, newPathData(), "Main.Tags[0]", "new")
+	if err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+
+	data, err = mapper.Set(testPathMessage, data, "ByName['bar']", map[string]any{"Name": "bar", "Tags": []any{}})
+	if err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+
+	data, err = mapper.Set(testPathMessage, data, "Number", "42")
+	if err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+
+	data, err = mapper.Set(testPathMessage, data, "Items[0].Name", "changed")
+	if err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+
+	data, err = mapper.Delete(testPathMessage, data, "Numbers[1]")
+	if err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+
+	data, err = mapper.Delete(testPathMessage, data, "ByName['foo']")
+	if err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+
+	expected := map[string]any{
+		"Items": []any{
+			map[string]any{"Name": "changed", "Tags": []any{"a"}},
+			map[string]any{"Name": "second", "Tags": []any{}},
+		},
+		"ByName": map[string]any{
+			"bar": map[string]any{"Name": "bar", "Tags": []any{}},
+		},
+		"Main":    map[string]any{"Name": "", "Tags": []any{"new"}},
+		"Number":  int64(42),
+		"Numbers": []any{int64(1), int64(3)},
+		"ById":    map[string]any{"7": "seven"},
+	}
+
+	if !reflect.DeepEqual(expected, data) {
+		t.Fatalf("expected %v, got %v", expected, data)
+	}
+
+	if _, err := mapper.Set(testPathMessage, newPathData(), "Numbers[0]", "not a number"); err == nil {
+		t.Fatal("expected conversion error")
+	}
+
+	binary, err := mapper.Encode(newPathData(), testPathMessage)
+	if err != nil {
+		t.Fatalf("map input encoding failed: %v", err)
+	}
+
+	result, err := mapper.Set(testPathMessage, binary, "Numbers", []any{9})
+	if err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+
+	numbers, err := mapper.Get(testPathMessage, result, "Numbers")
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+
+	if !reflect.DeepEqual([]any{int64(9)}, numbers) {
+		t.Fatalf("expected replaced list in binary, got %v", numbers)
+	}
+}
+
+func TestPath_EnumMap(t *testing.T) {
+	mapper, err := protomap.NewMapper(nil, "./testdata/maps.proto")
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	input := map[string]any{"States": map[string]any{"foo": "ACTIVE"}}
+
+	data, err := mapper.Set("protomap.test.WithEnumMap", input, "States['bar']", 2)
+	if err != nil {
+		t.Fatalf("set failed: %v", err)
+	}
+
+	state, err := mapper.Get("protomap.test.WithEnumMap", data, "States['bar']")
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+
+	if state != "DISABLED" {
+		t.Fatalf("expected DISABLED, got %v", state)
+	}
+
+	states, err := mapper.Get("protomap.test.WithEnumMap", data, "States")
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+
+	expected := map[string]any{"foo": "ACTIVE", "bar": "DISABLED"}
+	if !reflect.DeepEqual(expected, states) {
+		t.Fatalf("expected %v, got %v", expected, states)
+	}
+}
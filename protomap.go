@@ -3,18 +3,29 @@ package protomap
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/bufbuild/protocompile"
 	"github.com/bufbuild/protocompile/linker"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 var (
 	ErrNoSuchFile    = errors.New("no such file")
 	ErrNoSuchMessage = errors.New("no such message in descriptor")
+	ErrNoSuchMethod  = errors.New("no such method in descriptor")
+	ErrNoSuchService = errors.New("no such service in descriptor")
 )
 
 type Mapper struct {
-	r linker.Resolver
+	r             linker.Resolver
+	files         linker.Files
+	registry      *Registry
+	constraints   bool
+	deterministic bool
+	strict        bool
+	options       *optionsCache
 }
 
 func NewMapper(compiler *protocompile.Compiler, files ...string) (*Mapper, error) {
@@ -29,5 +40,68 @@ func NewMapper(compiler *protocompile.Compiler, files ...string) (*Mapper, error
 		return nil, err
 	}
 
-	return &Mapper{r: f.AsResolver()}, nil
+	return &Mapper{r: f.AsResolver(), files: f, options: &optionsCache{}}, nil
+}
+
+func (m *Mapper) findMessage(messageName string) (protoreflect.MessageDescriptor, error) {
+	desc, err := m.r.FindMessageByName(protoreflect.FullName(messageName))
+	if err != nil {
+		return nil, err
+	}
+
+	if desc == nil {
+		return nil, ErrNoSuchMessage
+	}
+
+	return desc.Descriptor(), nil
+}
+
+// FindMessage looks up message by full name, like "pkg.Message".
+func (m *Mapper) FindMessage(messageName string) (protoreflect.MessageDescriptor, error) {
+	desc, err := m.findMessage(messageName)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNoSuchMessage, messageName)
+	}
+	return desc, nil
+}
+
+// Resolver returns resolver of all descriptors known to Mapper, including imports of compiled files.
+func (m *Mapper) Resolver() linker.Resolver {
+	return m.r
+}
+
+// Files returns compiled files passed to NewMapper, without their imports.
+func (m *Mapper) Files() linker.Files {
+	return m.files
+}
+
+// FindMethod looks up method by full name, "pkg.Service.Method" or "/pkg.Service/Method".
+func (m *Mapper) FindMethod(methodName string) (protoreflect.MethodDescriptor, error) {
+	methodName = strings.ReplaceAll(strings.TrimPrefix(methodName, "/"), "/", ".")
+	desc, err := m.r.FindDescriptorByName(protoreflect.FullName(methodName))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNoSuchMethod, methodName)
+	}
+
+	method, ok := desc.(protoreflect.MethodDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ErrNoSuchMethod, methodName)
+	}
+
+	return method, nil
+}
+
+// FindService looks up service by full name, like "pkg.Service".
+func (m *Mapper) FindService(serviceName string) (protoreflect.ServiceDescriptor, error) {
+	desc, err := m.r.FindDescriptorByName(protoreflect.FullName(serviceName))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNoSuchService, serviceName)
+	}
+
+	service, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ErrNoSuchService, serviceName)
+	}
+
+	return service, nil
 }
@@ -3,6 +3,10 @@ package protomap_test
 import (
 	"encoding/base64"
 	"fmt"
+	"testing"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/gekatateam/protomap"
 )
 
 const (
@@ -15,8 +19,23 @@ const (
 	testIntersBinary  = "./testdata/withtimeduration.binpb"
 	testIntersJson    = "./testdata/withtimeduration.json"
 	testIntersMessage = "protomap.test.WithTimeDuration"
+
+	testWellKnownProto = "./testdata/wellknown.proto"
 )
 
+func newWellKnownMapper(t *testing.T) *protomap.Mapper {
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{}),
+	}
+
+	mapper, err := protomap.NewMapper(&compiler, testWellKnownProto)
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	return mapper
+}
+
 func setExpectedKeysWithTypes(in map[string]any) (map[string]any, error) {
 	var err error
 	in["Binary"], err = base64.StdEncoding.DecodeString(in["Binary"].(string))
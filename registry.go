@@ -0,0 +1,181 @@
+package protomap
+
+import (
+	"reflect"
+	"sync"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Registry maps message full names to interceptors, so they are dispatched by a single map lookup
+// instead of checking every interceptor for every message.
+// Registry is not safe for concurrent registration; fill it before use.
+type Registry struct {
+	encoders map[protoreflect.FullName]EncodeInterceptor
+	decoders map[protoreflect.FullName]DecodeInterceptor
+	schemas  map[protoreflect.FullName]map[string]any
+	fields   []fieldInterceptor
+}
+
+// FieldSelector reports whether field interceptor must be applied to field.
+// Path is a dot-separated field names from the top-level message, like "Inner.List";
+// list and map elements share path of their field.
+type FieldSelector func(field protoreflect.FieldDescriptor, path string) bool
+
+type fieldInterceptor struct {
+	selector FieldSelector
+	enc      FieldEncodeInterceptor
+	dec      FieldDecodeInterceptor
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		encoders: make(map[protoreflect.FullName]EncodeInterceptor),
+		decoders: make(map[protoreflect.FullName]DecodeInterceptor),
+		schemas:  make(map[protoreflect.FullName]map[string]any),
+	}
+}
+
+// Register sets interceptors for message; any of them may be nil.
+// Registering the same name again replaces previous interceptors and removes schema set by RegisterSchema.
+func (r *Registry) Register(messageName string, enc EncodeInterceptor, dec DecodeInterceptor) {
+	name := protoreflect.FullName(messageName)
+	delete(r.schemas, name)
+
+	delete(r.encoders, name)
+	if enc != nil {
+		r.encoders[name] = enc
+	}
+
+	delete(r.decoders, name)
+	if dec != nil {
+		r.decoders[name] = dec
+	}
+}
+
+// RegisterSchema sets JSON Schema of values that registered interceptors accept and return for message,
+// used by JSONSchema and OpenAPI; without it registered message is described as any value.
+// Call it after Register, which removes previous schema.
+func (r *Registry) RegisterSchema(messageName string, schema map[string]any) {
+	r.schemas[protoreflect.FullName(messageName)] = schema
+}
+
+// RegisterField adds field interceptors applied to fields matched by selector; any of them may be nil.
+// Field interceptors are checked in registration order before regular field conversion.
+func (r *Registry) RegisterField(selector FieldSelector, enc FieldEncodeInterceptor, dec FieldDecodeInterceptor) {
+	r.fields = append(r.fields, fieldInterceptor{
+		selector: selector,
+		enc:      enc,
+		dec:      dec,
+	})
+}
+
+// FieldByName selects field by full name, like "my.package.Message.field".
+func FieldByName(fullName string) FieldSelector {
+	return func(field protoreflect.FieldDescriptor, _ string) bool {
+		return field.FullName() == protoreflect.FullName(fullName)
+	}
+}
+
+// FieldByPath selects field by path from the top-level message, like "Inner.List".
+func FieldByPath(path string) FieldSelector {
+	return func(_ protoreflect.FieldDescriptor, fieldPath string) bool {
+		return fieldPath == path
+	}
+}
+
+// FieldByOption selects field that has custom option set, like "my.package.format";
+// if value is not nil, option value must be equal to it. Result is memoized per field descriptor.
+func FieldByOption(optionName string, value any) FieldSelector {
+	var selected sync.Map
+	return func(field protoreflect.FieldDescriptor, _ string) bool {
+		if ok, found := selected.Load(field); found {
+			return ok.(bool)
+		}
+
+		option, ok := FindOption(field, optionName)
+		ok = ok && (value == nil || reflect.DeepEqual(option.Interface(), value))
+		selected.Store(field, ok)
+		return ok
+	}
+}
+
+// EncodeInterceptor returns interceptor that dispatches message to registered encoder.
+func (r *Registry) EncodeInterceptor() EncodeInterceptor {
+	return func(input any, message protoreflect.Message) (applied bool, err error) {
+		enc, ok := r.encoders[message.Descriptor().FullName()]
+		if !ok {
+			return false, nil
+		}
+		return enc(input, message)
+	}
+}
+
+// DecodeInterceptor returns interceptor that dispatches message to registered decoder.
+func (r *Registry) DecodeInterceptor() DecodeInterceptor {
+	return func(message protoreflect.Message) (result any, applied bool, err error) {
+		dec, ok := r.decoders[message.Descriptor().FullName()]
+		if !ok {
+			return nil, false, nil
+		}
+		return dec(message)
+	}
+}
+
+// WithRegistry returns Mapper copy that applies registry interceptors on every call.
+// Per-call interceptors are applied before registry ones, so they may override it.
+func (m *Mapper) WithRegistry(registry *Registry) *Mapper {
+	mapper := *m
+	mapper.registry = registry
+	return &mapper
+}
+
+// MessageToAny converts message like package level MessageToAny does, applying registry interceptors, if any,
+// and checking constraints, if enabled.
+func (m *Mapper) MessageToAny(message protoreflect.Message, inters ...DecodeInterceptor) (any, error) {
+	if m.constraints {
+		if err := CheckConstraints(message); err != nil {
+			return nil, err
+		}
+	}
+
+	d := decoder{inters: m.DecodeInterceptors(inters...), options: m.options}
+	if m.registry != nil {
+		d.fields = m.registry.fields
+	}
+	return d.messageToAny(message, "")
+}
+
+// AnyToMessage converts input like package level AnyToMessage does, applying registry interceptors, if any,
+// and checking constraints, if enabled.
+func (m *Mapper) AnyToMessage(input any, message protoreflect.Message, inters ...EncodeInterceptor) error {
+	e := encoder{inters: m.EncodeInterceptors(inters...), options: m.options}
+	if m.registry != nil {
+		e.fields = m.registry.fields
+	}
+
+	if err := e.anyToMessage(input, message, ""); err != nil {
+		return err
+	}
+
+	if m.constraints {
+		return CheckConstraints(message)
+	}
+	return nil
+}
+
+// EncodeInterceptors returns per-call interceptors followed by registry dispatcher, if any.
+func (m *Mapper) EncodeInterceptors(inters ...EncodeInterceptor) []EncodeInterceptor {
+	if m.registry == nil {
+		return inters
+	}
+	return append(inters[:len(inters):len(inters)], m.registry.EncodeInterceptor())
+}
+
+// DecodeInterceptors returns per-call interceptors followed by registry dispatcher, if any.
+func (m *Mapper) DecodeInterceptors(inters ...DecodeInterceptor) []DecodeInterceptor {
+	if m.registry == nil {
+		return inters
+	}
+	return append(inters[:len(inters):len(inters)], m.registry.DecodeInterceptor())
+}
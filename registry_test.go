@@ -0,0 +1,262 @@
+package protomap_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/gekatateam/protomap"
+	"github.com/gekatateam/protomap/interceptors"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestRegistry_AppliedWithOverrides(t *testing.T) {
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{}),
+	}
+
+	plain, err := protomap.NewMapper(&compiler, testIntersProto)
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	registry := protomap.NewRegistry()
+	interceptors.RegisterWellKnown(registry)
+	mapper := plain.WithRegistry(registry).WithDeterministic()
+
+	binary, err := os.ReadFile(testIntersBinary)
+	if err != nil {
+		t.Fatalf("binary data reading failed: %v", err)
+	}
+
+	ts := time.Date(2025, 7, 30, 10, 1, 15, 0, time.UTC)
+
+	result, err := mapper.Decode(binary, testIntersMessage)
+	if err != nil {
+		t.Fatalf("binary data decoding failed: %v", err)
+	}
+
+	expected := map[string]any{"Ts": ts, "Dur": 13 * time.Second}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	result, err = mapper.Decode(binary, testIntersMessage, interceptors.RFC3339TimeDecoder)
+	if err != nil {
+		t.Fatalf("binary data decoding failed: %v", err)
+	}
+
+	expected = map[string]any{"Ts": "2025-07-30T10:01:15Z", "Dur": 13 * time.Second}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected per-call override %v, got %v", expected, result)
+	}
+
+	encoded, err := mapper.Encode(map[string]any{"Ts": "2025-07-30T10:01:15Z", "Dur": "13s"}, testIntersMessage)
+	if err != nil {
+		t.Fatalf("map input encoding failed: %v", err)
+	}
+
+	if !bytes.Equal(encoded, binary) {
+		t.Fatalf("expected %v, got %v", binary, encoded)
+	}
+
+	result, err = plain.Decode(binary, testIntersMessage)
+	if err != nil {
+		t.Fatalf("binary data decoding failed: %v", err)
+	}
+
+	if _, ok := result.(map[string]any)["Ts"].(map[string]any); !ok {
+		t.Fatalf("expected original mapper to stay without registry, got %v", result)
+	}
+}
+
+func TestRegistry_Any(t *testing.T) {
+	plain := newWellKnownMapper(t)
+
+	registry := protomap.NewRegistry()
+	interceptors.RegisterWellKnown(registry)
+	registry.Register("google.protobuf.Any",
+		interceptors.AnyEncoder(plain, registry.EncodeInterceptor()),
+		interceptors.AnyDecoder(plain, registry.DecodeInterceptor()),
+	)
+	mapper := plain.WithRegistry(registry)
+
+	input := map[string]any{
+		"Any": map[string]any{
+			"@type":  "protomap.test.WithStruct",
+			"Struct": map[string]any{"key": "value"},
+		},
+		"List": []any{},
+	}
+
+	binary, err := mapper.Encode(input, "protomap.test.WithAny")
+	if err != nil {
+		t.Fatalf("map input encoding failed: %v", err)
+	}
+
+	result, err := mapper.Decode(binary, "protomap.test.WithAny")
+	if err != nil {
+		t.Fatalf("binary data decoding failed: %v", err)
+	}
+
+	expected := map[string]any{
+		"Any": map[string]any{
+			"@type":  "type.googleapis.com/protomap.test.WithStruct",
+			"Struct": map[string]any{"key": "value"},
+			"Value":  nil,
+			"List":   nil,
+			"Null":   nil,
+		},
+		"List":  []any{},
+		"Empty": nil,
+	}
+
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestRegistry_AnyWithFieldInterceptors(t *testing.T) {
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{}),
+	}
+
+	plain, err := protomap.NewMapper(&compiler, testWellKnownProto, "./testdata/fields.proto")
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	maskDecoder := func(_ protoreflect.FieldDescriptor, _ protoreflect.Value) (any, bool, error) {
+		return "***", true, nil
+	}
+
+	registry := protomap.NewRegistry()
+	registry.RegisterField(protomap.FieldByPath("Nested.Secret"), nil, maskDecoder)
+	mapper := plain.WithRegistry(registry)
+	registry.Register("google.protobuf.Any", interceptors.AnyEncoder(mapper), interceptors.AnyDecoder(mapper))
+
+	input := map[string]any{
+		"Any": map[string]any{
+			"@type":     "protomap.test.WithFields",
+			"Id":        []byte{1},
+			"CreatedMs": int64(0),
+			"Refs":      []any{},
+			"Nested":    map[string]any{"Secret": "password"},
+		},
+		"List": []any{},
+	}
+
+	binary, err := mapper.Encode(input, "protomap.test.WithAny")
+	if err != nil {
+		t.Fatalf("map input encoding failed: %v", err)
+	}
+
+	result, err := mapper.Decode(binary, "protomap.test.WithAny")
+	if err != nil {
+		t.Fatalf("binary data decoding failed: %v", err)
+	}
+
+	secret := result.(map[string]any)["Any"].(map[string]any)["Nested"].(map[string]any)["Secret"]
+	if secret != "***" {
+		t.Fatalf("expected field interceptor applied in embedded message, got %v", secret)
+	}
+}
+
+func TestRegistry_FieldInterceptors(t *testing.T) {
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{}),
+	}
+
+	plain, err := protomap.NewMapper(&compiler, "./testdata/fields.proto")
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	uuidDecoder := func(field protoreflect.FieldDescriptor, value protoreflect.Value) (any, bool, error) {
+		b := value.Bytes()
+		if len(b) != 16 {
+			return nil, true, fmt.Errorf("%v: expected 16 bytes, got %v", field.Name(), len(b))
+		}
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), true, nil
+	}
+
+	uuidEncoder := func(field protoreflect.FieldDescriptor, input any) (protoreflect.Value, bool, error) {
+		s, ok := input.(string)
+		if !ok {
+			return protoreflect.Value{}, false, nil
+		}
+
+		b, err := hex.DecodeString(strings.ReplaceAll(s, "-", ""))
+		if err != nil {
+			return protoreflect.Value{}, true, err
+		}
+		return protoreflect.ValueOfBytes(b), true, nil
+	}
+
+	msDecoder := func(_ protoreflect.FieldDescriptor, value protoreflect.Value) (any, bool, error) {
+		return time.UnixMilli(value.Int()).UTC(), true, nil
+	}
+
+	msEncoder := func(_ protoreflect.FieldDescriptor, input any) (protoreflect.Value, bool, error) {
+		t, ok := input.(time.Time)
+		if !ok {
+			return protoreflect.Value{}, false, nil
+		}
+		return protoreflect.ValueOfInt64(t.UnixMilli()), true, nil
+	}
+
+	maskDecoder := func(_ protoreflect.FieldDescriptor, _ protoreflect.Value) (any, bool, error) {
+		return "***", true, nil
+	}
+
+	registry := protomap.NewRegistry()
+	registry.RegisterField(protomap.FieldByName("protomap.test.WithFields.Id"), uuidEncoder, uuidDecoder)
+	registry.RegisterField(protomap.FieldByName("protomap.test.WithFields.Refs"), uuidEncoder, uuidDecoder)
+	registry.RegisterField(protomap.FieldByOption("protomap.test.format", "unix_ms"), msEncoder, msDecoder)
+	registry.RegisterField(protomap.FieldByPath("Nested.Secret"), nil, maskDecoder)
+	mapper := plain.WithRegistry(registry)
+
+	created := time.Date(2025, 1, 2, 3, 4, 5, 6e6, time.UTC)
+	input := map[string]any{
+		"Id":        "123e4567-e89b-12d3-a456-426614174000",
+		"CreatedMs": created,
+		"Refs":      []any{"00000000-0000-0000-0000-000000000001"},
+		"Nested":    map[string]any{"Secret": "password"},
+	}
+
+	binary, err := mapper.Encode(input, "protomap.test.WithFields")
+	if err != nil {
+		t.Fatalf("map input encoding failed: %v", err)
+	}
+
+	result, err := mapper.Decode(binary, "protomap.test.WithFields")
+	if err != nil {
+		t.Fatalf("binary data decoding failed: %v", err)
+	}
+
+	expected := map[string]any{
+		"Id":        "123e4567-e89b-12d3-a456-426614174000",
+		"CreatedMs": created,
+		"Refs":      []any{"00000000-0000-0000-0000-000000000001"},
+		"Nested":    map[string]any{"Secret": "***"},
+	}
+
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	result, err = plain.Decode(binary, "protomap.test.WithFields")
+	if err != nil {
+		t.Fatalf("binary data decoding failed: %v", err)
+	}
+
+	if result.(map[string]any)["CreatedMs"] != created.UnixMilli() {
+		t.Fatalf("expected raw millis without registry, got %v", result.(map[string]any)["CreatedMs"])
+	}
+}
@@ -0,0 +1,234 @@
+package sample
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+
+	"github.com/gekatateam/protomap"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const letters = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// Generator produces random maps, that Encode accepts, for messages described by Mapper.
+// Generator is not safe for concurrent use.
+type Generator struct {
+	mapper *protomap.Mapper
+	rand   *rand.Rand
+
+	// MaxDepth limits nesting of messages; deeper message fields are nil, lists and maps of messages are empty
+	MaxDepth int
+	// MaxItems limits length of lists and maps
+	MaxItems int
+}
+
+// NewGenerator returns generator with seeded RNG, so the same seed produces the same samples.
+func NewGenerator(mapper *protomap.Mapper, seed int64) *Generator {
+	return &Generator{
+		mapper:   mapper,
+		rand:     rand.New(rand.NewSource(seed)),
+		MaxDepth: 3,
+		MaxItems: 3,
+	}
+}
+
+// Generate returns random map for message. Every field is set, except non-chosen members of oneofs
+// and fields with (protomap.skip) option; enums are set by name, bytes with (protomap.format) - by formatted string.
+// google.protobuf.Any fields are nil, as embedded message cannot be chosen.
+func (g *Generator) Generate(messageName string) (map[string]any, error) {
+	desc, err := g.mapper.FindMessage(messageName)
+	if err != nil {
+		return nil, err
+	}
+	return g.message(desc, 0), nil
+}
+
+// Template returns map for message with zero values of every field, including all members of oneofs;
+// enums are set to the first value name, nested messages - to templates up to MaxDepth.
+func (g *Generator) Template(messageName string) (map[string]any, error) {
+	desc, err := g.mapper.FindMessage(messageName)
+	if err != nil {
+		return nil, err
+	}
+	return g.template(desc, 0), nil
+}
+
+func (g *Generator) message(desc protoreflect.MessageDescriptor, depth int) map[string]any {
+	fields := desc.Fields()
+	result := make(map[string]any, fields.Len())
+
+	chosen := make(map[protoreflect.Name]protoreflect.FieldDescriptor)
+	oneOfs := desc.Oneofs()
+	for i := 0; i < oneOfs.Len(); i++ {
+		oneOf := oneOfs.Get(i)
+		if oneOf.IsSynthetic() {
+			continue
+		}
+
+		var members []protoreflect.FieldDescriptor
+		for j := 0; j < oneOf.Fields().Len(); j++ {
+			if member := oneOf.Fields().Get(j); !g.mapper.IsSkipped(member) {
+				members = append(members, member)
+			}
+		}
+
+		if len(members) > 0 {
+			chosen[oneOf.Name()] = members[g.rand.Intn(len(members))]
+		}
+	}
+
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if g.mapper.IsSkipped(field) {
+			continue
+		}
+
+		if oneOf := field.ContainingOneof(); oneOf != nil && !oneOf.IsSynthetic() && chosen[oneOf.Name()] != field {
+			continue
+		}
+
+		switch {
+		case field.IsList():
+			list := []any{}
+			if field.Message() == nil || depth < g.MaxDepth {
+				for j := g.rand.Intn(g.MaxItems + 1); j > 0; j-- {
+					list = append(list, g.value(field, field.Kind(), field.Message(), depth))
+				}
+			}
+			result[string(field.Name())] = list
+		case field.IsMap():
+			gomap := map[string]any{}
+			if field.MapValue().Message() == nil || depth < g.MaxDepth {
+				for j := g.rand.Intn(g.MaxItems + 1); j > 0; j-- {
+					key := fmt.Sprint(g.scalar(field.MapKey(), field.MapKey().Kind()))
+					gomap[key] = g.value(field, field.MapValue().Kind(), field.MapValue().Message(), depth)
+				}
+			}
+			result[string(field.Name())] = gomap
+		default:
+			result[string(field.Name())] = g.value(field, field.Kind(), field.Message(), depth)
+		}
+	}
+
+	return result
+}
+
+func (g *Generator) value(field protoreflect.FieldDescriptor, kind protoreflect.Kind, message protoreflect.MessageDescriptor, depth int) any {
+	if message != nil {
+		if depth >= g.MaxDepth || message.FullName() == "google.protobuf.Any" {
+			return nil
+		}
+		return g.message(message, depth+1)
+	}
+
+	if format, ok := g.mapper.FieldFormat(field); ok && kind == protoreflect.BytesKind {
+		b := make([]byte, 16)
+		g.rand.Read(b)
+
+		switch format {
+		case "uuid":
+			return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+		case "hex":
+			return hex.EncodeToString(b)
+		case "base64":
+			return base64.StdEncoding.EncodeToString(b)
+		}
+	}
+
+	return g.scalar(field, kind)
+}
+
+// scalar returns random value of the same Go type as Decode returns for kind.
+func (g *Generator) scalar(field protoreflect.FieldDescriptor, kind protoreflect.Kind) any {
+	switch kind {
+	case protoreflect.BoolKind:
+		return g.rand.Intn(2) == 1
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return g.rand.Int63n(2001) - 1000
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return uint64(g.rand.Int63n(1001))
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		// float32 precision, so value is the same after float fields roundtrip
+		return float64(float32(g.rand.Float64() * 1000))
+	case protoreflect.StringKind:
+		return g.word(field.Name())
+	case protoreflect.BytesKind:
+		b := make([]byte, 1+g.rand.Intn(16))
+		g.rand.Read(b)
+		return b
+	case protoreflect.EnumKind:
+		enumDesc := field.Enum()
+		if field.IsMap() {
+			enumDesc = field.MapValue().Enum()
+		}
+
+		values := enumDesc.Values()
+		return string(values.Get(g.rand.Intn(values.Len())).Name())
+	default:
+		return nil
+	}
+}
+
+func (g *Generator) word(name protoreflect.Name) string {
+	b := make([]byte, 1+g.rand.Intn(8))
+	for i := range b {
+		b[i] = letters[g.rand.Intn(len(letters))]
+	}
+	return string(name) + "_" + string(b)
+}
+
+func (g *Generator) template(desc protoreflect.MessageDescriptor, depth int) map[string]any {
+	fields := desc.Fields()
+	result := make(map[string]any, fields.Len())
+
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if g.mapper.IsSkipped(field) {
+			continue
+		}
+
+		switch {
+		case field.IsList():
+			result[string(field.Name())] = []any{}
+		case field.IsMap():
+			result[string(field.Name())] = map[string]any{}
+		case field.Message() != nil:
+			if depth >= g.MaxDepth {
+				result[string(field.Name())] = nil
+				continue
+			}
+			result[string(field.Name())] = g.template(field.Message(), depth+1)
+		default:
+			result[string(field.Name())] = zero(field)
+		}
+	}
+
+	return result
+}
+
+func zero(field protoreflect.FieldDescriptor) any {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		return false
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return int64(0)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return uint64(0)
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return float64(0)
+	case protoreflect.StringKind:
+		return ""
+	case protoreflect.BytesKind:
+		return []byte{}
+	case protoreflect.EnumKind:
+		return string(field.Enum().Values().Get(0).Name())
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,169 @@
+package sample_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/gekatateam/protomap"
+	"github.com/gekatateam/protomap/sample"
+)
+
+const (
+	testProto   = "../testdata/payload.proto"
+	testMessage = "protomap.test.Test"
+)
+
+func TestGenerator_Generate(t *testing.T) {
+	mapper, err := protomap.NewMapper(nil, testProto)
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	generator := sample.NewGenerator(mapper, 42)
+	for i := 0; i < 20; i++ {
+		data, err := generator.Generate(testMessage)
+		if err != nil {
+			t.Fatalf("sample generation failed: %v", err)
+		}
+
+		if err := mapper.Validate(data, testMessage); err != nil {
+			t.Fatalf("expected valid sample %v, got %v", data, err)
+		}
+
+		if _, ok := data["Type"]; ok == (data["Number"] != nil) {
+			t.Fatalf("expected exactly one oneof field, got %v", data)
+		}
+
+		binary, err := mapper.Encode(data, testMessage)
+		if err != nil {
+			t.Fatalf("sample encoding failed: %v", err)
+		}
+
+		if _, err := mapper.Decode(binary, testMessage); err != nil {
+			t.Fatalf("sample decoding failed: %v", err)
+		}
+	}
+
+	first, _ := sample.NewGenerator(mapper, 7).Generate(testMessage)
+	second, _ := sample.NewGenerator(mapper, 7).Generate(testMessage)
+	if !reflect.DeepEqual(first, second) {
+		t.Fatalf("expected the same samples for the same seed, got %v and %v", first, second)
+	}
+}
+
+func TestGenerator_GenerateWithOptions(t *testing.T) {
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(protomap.WithOptionsImport(&protocompile.SourceResolver{})),
+	}
+
+	mapper, err := protomap.NewMapper(&compiler, "../testdata/options.proto")
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	generator := sample.NewGenerator(mapper, 42)
+	generator.MaxDepth = 1
+
+	data, err := generator.Generate("protomap.test.WithOptions")
+	if err != nil {
+		t.Fatalf("sample generation failed: %v", err)
+	}
+
+	if _, ok := data["Internal"]; ok {
+		t.Fatalf("expected skipped field to be absent, got %v", data)
+	}
+
+	binary, err := mapper.Encode(data, "protomap.test.WithOptions")
+	if err != nil {
+		t.Fatalf("sample encoding failed: %v", err)
+	}
+
+	result, err := mapper.Decode(binary, "protomap.test.WithOptions")
+	if err != nil {
+		t.Fatalf("sample decoding failed: %v", err)
+	}
+
+	if data["Id"] != result.(map[string]any)["Id"] {
+		t.Fatalf("expected uuid %v, got %v", data["Id"], result.(map[string]any)["Id"])
+	}
+}
+
+func TestGenerator_SkippedOneofMember(t *testing.T) {
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(protomap.WithOptionsImport(&protocompile.SourceResolver{})),
+	}
+
+	mapper, err := protomap.NewMapper(&compiler, "../testdata/options.proto")
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	for seed := range int64(20) {
+		data, err := sample.NewGenerator(mapper, seed).Generate("protomap.test.WithSkippedOneof")
+		if err != nil {
+			t.Fatalf("sample generation failed: %v", err)
+		}
+
+		if _, ok := data["Number"]; !ok || len(data) != 1 {
+			t.Fatalf("seed %v: expected not skipped oneof member, got %v", seed, data)
+		}
+	}
+}
+
+func TestGenerator_EnumMap(t *testing.T) {
+	mapper, err := protomap.NewMapper(nil, "../testdata/maps.proto")
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	generator := sample.NewGenerator(mapper, 42)
+	for i := 0; i < 20; i++ {
+		data, err := generator.Generate("protomap.test.WithEnumMap")
+		if err != nil {
+			t.Fatalf("sample generation failed: %v", err)
+		}
+
+		if err := mapper.Validate(data, "protomap.test.WithEnumMap"); err != nil {
+			t.Fatalf("expected valid sample %v, got %v", data, err)
+		}
+	}
+}
+
+func TestGenerator_Template(t *testing.T) {
+	mapper, err := protomap.NewMapper(nil, testProto)
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	generator := sample.NewGenerator(mapper, 0)
+	generator.MaxDepth = 0
+
+	template, err := generator.Template(testMessage)
+	if err != nil {
+		t.Fatalf("template generation failed: %v", err)
+	}
+
+	expected := map[string]any{
+		"String": "",
+		"Map":    map[string]any{},
+		"Binary": []byte{},
+		"List":   []any{},
+		"Int":    int64(0),
+		"Uint":   uint64(0),
+		"Float":  float64(0),
+		"Inner":  nil,
+		"IntMap": map[string]any{},
+		"Type":   "",
+		"Number": float64(0),
+		"Enum":   "OK",
+	}
+
+	if !reflect.DeepEqual(expected, template) {
+		t.Fatalf("expected %v, got %v", expected, template)
+	}
+
+	if _, err := generator.Template("protomap.test.Unknown"); err == nil {
+		t.Fatal("expected error for unknown message")
+	}
+}
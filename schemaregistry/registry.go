@@ -0,0 +1,88 @@
+package schemaregistry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var ErrSchemaNotFound = errors.New("schema not found")
+
+type Reference struct {
+	Name    string
+	Subject string
+	Version int
+}
+
+type Schema struct {
+	ID         int
+	Schema     string
+	References []Reference
+}
+
+// Client is a schema registry lookup interface.
+type Client interface {
+	SchemaByID(ctx context.Context, id int) (Schema, error)
+	SchemaBySubjectVersion(ctx context.Context, subject string, version int) (Schema, error)
+}
+
+// MemoryClient is an in-memory Client implementation, useful for tests.
+type MemoryClient struct {
+	mu       sync.RWMutex
+	byID     map[int]Schema
+	subjects map[string][]int
+}
+
+func NewMemoryClient() *MemoryClient {
+	return &MemoryClient{
+		byID:     make(map[int]Schema),
+		subjects: make(map[string][]int),
+	}
+}
+
+// Register stores schema as the next version of subject and returns its ID.
+func (c *MemoryClient) Register(subject string, schema string, refs ...Reference) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := len(c.byID) + 1
+	c.byID[id] = Schema{
+		ID:         id,
+		Schema:     schema,
+		References: refs,
+	}
+	c.subjects[subject] = append(c.subjects[subject], id)
+
+	return id
+}
+
+func (c *MemoryClient) SchemaByID(_ context.Context, id int) (Schema, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	schema, ok := c.byID[id]
+	if !ok {
+		return Schema{}, fmt.Errorf("%w: id %v", ErrSchemaNotFound, id)
+	}
+
+	return schema, nil
+}
+
+// SchemaBySubjectVersion returns schema by subject and version;
+// versions start from 1, and -1 means the latest one.
+func (c *MemoryClient) SchemaBySubjectVersion(_ context.Context, subject string, version int) (Schema, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	ids := c.subjects[subject]
+	if version == -1 {
+		version = len(ids)
+	}
+
+	if version < 1 || version > len(ids) {
+		return Schema{}, fmt.Errorf("%w: subject %v version %v", ErrSchemaNotFound, subject, version)
+	}
+
+	return c.byID[ids[version-1]], nil
+}
@@ -0,0 +1,224 @@
+package schemaregistry
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/gekatateam/protomap"
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// fetchTimeout bounds shared fetch of schema, which is not canceled with context of any single caller.
+const fetchTimeout = 30 * time.Second
+
+// Serde encodes and decodes messages framed with Confluent Schema Registry wire format.
+// Schemas fetched from registry are compiled once and cached by ID;
+// concurrent lookups of the same missing schema share one fetch.
+type Serde struct {
+	client Client
+	group  singleflight.Group
+
+	mu      sync.RWMutex
+	schemas map[int]*compiledSchema
+}
+
+type compiledSchema struct {
+	mapper *protomap.Mapper
+	file   protoreflect.FileDescriptor
+}
+
+func NewSerde(client Client) *Serde {
+	return &Serde{
+		client:  client,
+		schemas: make(map[int]*compiledSchema),
+	}
+}
+
+// Serialize encodes data as messageName from schema with given ID and prepends wire format header.
+func (s *Serde) Serialize(ctx context.Context, schemaID int, messageName string, data any, inters ...protomap.EncodeInterceptor) ([]byte, error) {
+	schema, err := s.schema(ctx, schemaID)
+	if err != nil {
+		return nil, err
+	}
+
+	desc, _ := schema.mapper.Resolver().FindDescriptorByName(protoreflect.FullName(messageName))
+	message, ok := desc.(protoreflect.MessageDescriptor)
+	if !ok || message.ParentFile().Path() != schema.file.Path() {
+		return nil, fmt.Errorf("%w: %v in schema %v", protomap.ErrNoSuchMessage, messageName, schemaID)
+	}
+
+	payload, err := schema.mapper.Encode(data, messageName, inters...)
+	if err != nil {
+		return nil, err
+	}
+
+	header := Header{
+		SchemaID: schemaID,
+		Indexes:  MessageIndexes(message),
+	}
+
+	return append(AppendHeader(make([]byte, 0, 6+len(payload)), header), payload...), nil
+}
+
+// Deserialize parses wire format header, resolves message by schema ID and indexes path,
+// and decodes the rest of data.
+func (s *Serde) Deserialize(ctx context.Context, data []byte, inters ...protomap.DecodeInterceptor) (any, error) {
+	header, payload, err := ParseHeader(data)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := s.schema(ctx, header.SchemaID)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := MessageByIndexes(schema.file, header.Indexes)
+	if err != nil {
+		return nil, fmt.Errorf("schema %v: %w", header.SchemaID, err)
+	}
+
+	return schema.mapper.Decode(payload, string(message.FullName()), inters...)
+}
+
+// Mapper returns Mapper compiled from schema with given ID.
+func (s *Serde) Mapper(ctx context.Context, schemaID int) (*protomap.Mapper, error) {
+	schema, err := s.schema(ctx, schemaID)
+	if err != nil {
+		return nil, err
+	}
+	return schema.mapper, nil
+}
+
+func (s *Serde) schema(ctx context.Context, id int) (*compiledSchema, error) {
+	s.mu.RLock()
+	schema, ok := s.schemas[id]
+	s.mu.RUnlock()
+	if ok {
+		return schema, nil
+	}
+
+	// registry is not locked while fetching, so lookups of cached schemas are not blocked;
+	// fetch is shared, so it does not depend on cancellation of caller that started it,
+	// and every caller waits for it until its own context is done
+	ch := s.group.DoChan(strconv.Itoa(id), func() (any, error) {
+		// schema may be cached by previous call, which ended after the check above
+		s.mu.RLock()
+		schema, ok := s.schemas[id]
+		s.mu.RUnlock()
+		if ok {
+			return schema, nil
+		}
+
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), fetchTimeout)
+		defer cancel()
+
+		schema, err := s.fetch(fetchCtx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		s.mu.Lock()
+		s.schemas[id] = schema
+		s.mu.Unlock()
+		return schema, nil
+	})
+
+	select {
+	case result := <-ch:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		return result.Val.(*compiledSchema), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (s *Serde) fetch(ctx context.Context, id int) (*compiledSchema, error) {
+	root, err := s.client.SchemaByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	rootName := fmt.Sprintf("schema-%v.proto", id)
+	sources := map[string]string{rootName: root.Schema}
+	if err := s.collectReferences(ctx, root.References, sources); err != nil {
+		return nil, fmt.Errorf("schema %v: %w", id, err)
+	}
+
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{
+			Accessor: protocompile.SourceAccessorFromMap(sources),
+		}),
+	}
+
+	mapper, err := protomap.NewMapper(&compiler, rootName)
+	if err != nil {
+		return nil, fmt.Errorf("schema %v: %w", id, err)
+	}
+
+	file, err := mapper.Resolver().FindFileByPath(rootName)
+	if err != nil {
+		return nil, fmt.Errorf("schema %v: %w", id, err)
+	}
+
+	return &compiledSchema{mapper: mapper, file: file}, nil
+}
+
+func (s *Serde) collectReferences(ctx context.Context, refs []Reference, sources map[string]string) error {
+	for _, ref := range refs {
+		if _, ok := sources[ref.Name]; ok {
+			continue
+		}
+
+		schema, err := s.client.SchemaBySubjectVersion(ctx, ref.Subject, ref.Version)
+		if err != nil {
+			return fmt.Errorf("reference %v: %w", ref.Name, err)
+		}
+
+		sources[ref.Name] = schema.Schema
+		if err := s.collectReferences(ctx, schema.References, sources); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MessageIndexes returns path of message indexes from the top level of its file.
+func MessageIndexes(message protoreflect.MessageDescriptor) []int {
+	var indexes []int
+	var desc protoreflect.Descriptor = message
+	for {
+		if _, ok := desc.(protoreflect.MessageDescriptor); !ok {
+			break
+		}
+		indexes = append([]int{desc.Index()}, indexes...)
+		desc = desc.Parent()
+	}
+	return indexes
+}
+
+// MessageByIndexes resolves message in file by indexes path.
+func MessageByIndexes(file protoreflect.FileDescriptor, indexes []int) (protoreflect.MessageDescriptor, error) {
+	if len(indexes) == 0 {
+		return nil, fmt.Errorf("empty message indexes")
+	}
+
+	messages := file.Messages()
+	var message protoreflect.MessageDescriptor
+	for i, index := range indexes {
+		if index < 0 || index >= messages.Len() {
+			return nil, fmt.Errorf("%w: index %v at position %v is out of range", protomap.ErrNoSuchMessage, index, i)
+		}
+		message = messages.Get(index)
+		messages = message.Messages()
+	}
+
+	return message, nil
+}
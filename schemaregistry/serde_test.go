@@ -0,0 +1,265 @@
+package schemaregistry_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gekatateam/protomap"
+	"github.com/gekatateam/protomap/schemaregistry"
+)
+
+const commonSchema = `syntax = "proto3";
+
+package protomap.test;
+
+message Common {
+    string Id = 1;
+}
+`
+
+const eventSchema = `syntax = "proto3";
+
+package protomap.test;
+
+import "common.proto";
+
+message Event {
+    Common Common = 1;
+    string Name = 2;
+
+    message Nested {
+        int64 Value = 1;
+    }
+}
+
+message Other {
+    bool Flag = 1;
+}
+`
+
+func newTestSerde(t *testing.T) (*schemaregistry.Serde, int) {
+	client := schemaregistry.NewMemoryClient()
+	client.Register("common", commonSchema)
+	id := client.Register("events-value", eventSchema, schemaregistry.Reference{
+		Name:    "common.proto",
+		Subject: "common",
+		Version: -1,
+	})
+
+	return schemaregistry.NewSerde(client), id
+}
+
+func TestSerde_SerializeThenDeserialize(t *testing.T) {
+	serde, id := newTestSerde(t)
+
+	cases := []struct {
+		name    string
+		message string
+		indexes []int
+		input   map[string]any
+	}{
+		{
+			name:    "first message",
+			message: "protomap.test.Event",
+			indexes: []int{0},
+			input: map[string]any{
+				"Common": map[string]any{"Id": "42"},
+				"Name":   "created",
+			},
+		},
+		{
+			name:    "nested message",
+			message: "protomap.test.Event.Nested",
+			indexes: []int{0, 0},
+			input:   map[string]any{"Value": int64(7)},
+		},
+		{
+			name:    "second message",
+			message: "protomap.test.Other",
+			indexes: []int{1},
+			input:   map[string]any{"Flag": true},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			data, err := serde.Serialize(context.Background(), id, c.message, c.input)
+			if err != nil {
+				t.Fatalf("serialization failed: %v", err)
+			}
+
+			header, _, err := schemaregistry.ParseHeader(data)
+			if err != nil {
+				t.Fatalf("header parsing failed: %v", err)
+			}
+
+			if header.SchemaID != id || !reflect.DeepEqual(header.Indexes, c.indexes) {
+				t.Fatalf("unexpected header: %+v", header)
+			}
+
+			result, err := serde.Deserialize(context.Background(), data)
+			if err != nil {
+				t.Fatalf("deserialization failed: %v", err)
+			}
+
+			if !reflect.DeepEqual(c.input, result) {
+				t.Fatalf("expected %v, got %v", c.input, result)
+			}
+		})
+	}
+}
+
+func TestSerde_Errors(t *testing.T) {
+	serde, id := newTestSerde(t)
+
+	if _, err := serde.Serialize(context.Background(), id, "protomap.test.Unknown", map[string]any{}); !errors.Is(err, protomap.ErrNoSuchMessage) {
+		t.Fatalf("expected no such message error, got %v", err)
+	}
+
+	if _, err := serde.Deserialize(context.Background(), []byte{1, 0, 0, 0, 1, 0}); !errors.Is(err, schemaregistry.ErrInvalidMagicByte) {
+		t.Fatalf("expected invalid magic byte error, got %v", err)
+	}
+
+	if _, err := serde.Deserialize(context.Background(), []byte{0, 0, 0, 0, 99, 0}); !errors.Is(err, schemaregistry.ErrSchemaNotFound) {
+		t.Fatalf("expected schema not found error, got %v", err)
+	}
+
+	data := schemaregistry.AppendHeader(nil, schemaregistry.Header{SchemaID: id, Indexes: []int{5}})
+	if _, err := serde.Deserialize(context.Background(), data); !errors.Is(err, protomap.ErrNoSuchMessage) {
+		t.Fatalf("expected no such message error, got %v", err)
+	}
+}
+
+// blockingClient holds SchemaByID calls of blocked schema until release is closed.
+type blockingClient struct {
+	*schemaregistry.MemoryClient
+	blocked int
+	started chan struct{}
+	release chan struct{}
+	calls   atomic.Int32
+}
+
+func (c *blockingClient) SchemaByID(ctx context.Context, id int) (schemaregistry.Schema, error) {
+	if id == c.blocked {
+		if c.calls.Add(1) == 1 {
+			close(c.started)
+		}
+
+		select {
+		case <-c.release:
+		case <-ctx.Done():
+			return schemaregistry.Schema{}, ctx.Err()
+		}
+	}
+	return c.MemoryClient.SchemaByID(ctx, id)
+}
+
+func TestSerde_ConcurrentFetch(t *testing.T) {
+	memory := schemaregistry.NewMemoryClient()
+	memory.Register("common", commonSchema)
+	cached := memory.Register("other", `syntax = "proto3"; package protomap.test; message Flag { bool On = 1; }`)
+	blocked := memory.Register("events-value", eventSchema, schemaregistry.Reference{
+		Name:    "common.proto",
+		Subject: "common",
+		Version: -1,
+	})
+
+	client := &blockingClient{
+		MemoryClient: memory,
+		blocked:      blocked,
+		started:      make(chan struct{}),
+		release:      make(chan struct{}),
+	}
+	serde := schemaregistry.NewSerde(client)
+
+	if _, err := serde.Mapper(context.Background(), cached); err != nil {
+		t.Fatalf("schema fetching failed: %v", err)
+	}
+
+	wg := sync.WaitGroup{}
+	errs := make(chan error, 3)
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := serde.Mapper(context.Background(), blocked)
+			errs <- err
+		}()
+	}
+
+	<-client.started
+	if _, err := serde.Serialize(context.Background(), cached, "protomap.test.Flag", map[string]any{"On": true}); err != nil {
+		t.Fatalf("expected cached schema while other one is fetched, got %v", err)
+	}
+
+	close(client.release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("schema fetching failed: %v", err)
+		}
+	}
+
+	if calls := client.calls.Load(); calls != 1 {
+		t.Fatalf("expected single fetch of schema, got %v", calls)
+	}
+}
+
+func TestSerde_CanceledFetch(t *testing.T) {
+	memory := schemaregistry.NewMemoryClient()
+	memory.Register("common", commonSchema)
+	blocked := memory.Register("events-value", eventSchema, schemaregistry.Reference{
+		Name:    "common.proto",
+		Subject: "common",
+		Version: -1,
+	})
+
+	client := &blockingClient{
+		MemoryClient: memory,
+		blocked:      blocked,
+		started:      make(chan struct{}),
+		release:      make(chan struct{}),
+	}
+	serde := schemaregistry.NewSerde(client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := serde.Mapper(ctx, blocked)
+		first <- err
+	}()
+
+	<-client.started
+	second := make(chan error, 1)
+	go func() {
+		_, err := serde.Mapper(context.Background(), blocked)
+		second <- err
+	}()
+
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected canceled lookup, got %v", err)
+	}
+
+	close(client.release)
+	if err := <-second; err != nil {
+		t.Fatalf("expected schema fetched regardless of first caller cancellation, got %v", err)
+	}
+
+	if calls := client.calls.Load(); calls != 1 {
+		t.Fatalf("expected single fetch of schema, got %v", calls)
+	}
+}
+
+func TestHeader_FirstMessageShortcut(t *testing.T) {
+	data := schemaregistry.AppendHeader(nil, schemaregistry.Header{SchemaID: 1, Indexes: []int{0}})
+	if !bytes.Equal(data, []byte{0, 0, 0, 0, 1, 0}) {
+		t.Fatalf("unexpected header bytes: %v", data)
+	}
+}
@@ -0,0 +1,75 @@
+package schemaregistry
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+const magicByte byte = 0x0
+
+var (
+	ErrInvalidMagicByte = errors.New("invalid magic byte")
+	ErrShortHeader      = errors.New("data is too short to contain wire format header")
+)
+
+type Header struct {
+	SchemaID int
+	Indexes  []int
+}
+
+// ParseHeader reads Confluent wire format header and returns it with the rest of data.
+func ParseHeader(data []byte) (Header, []byte, error) {
+	if len(data) < 5 {
+		return Header{}, nil, ErrShortHeader
+	}
+
+	if data[0] != magicByte {
+		return Header{}, nil, fmt.Errorf("%w: %v", ErrInvalidMagicByte, data[0])
+	}
+
+	header := Header{SchemaID: int(binary.BigEndian.Uint32(data[1:5]))}
+
+	r := bytes.NewReader(data[5:])
+	count, err := binary.ReadVarint(r)
+	if err != nil {
+		return Header{}, nil, fmt.Errorf("message indexes count reading failed: %w", err)
+	}
+
+	// zero count is a shortcut for the first message in file
+	if count == 0 {
+		header.Indexes = []int{0}
+	}
+
+	if count < 0 || count > int64(r.Len()) {
+		return Header{}, nil, fmt.Errorf("invalid message indexes count: %v", count)
+	}
+
+	for i := int64(0); i < count; i++ {
+		index, err := binary.ReadVarint(r)
+		if err != nil {
+			return Header{}, nil, fmt.Errorf("message index %v reading failed: %w", i, err)
+		}
+		header.Indexes = append(header.Indexes, int(index))
+	}
+
+	return header, data[len(data)-r.Len():], nil
+}
+
+// AppendHeader appends Confluent wire format header to dst.
+func AppendHeader(dst []byte, header Header) []byte {
+	dst = append(dst, magicByte)
+	dst = binary.BigEndian.AppendUint32(dst, uint32(header.SchemaID))
+
+	if len(header.Indexes) == 0 || (len(header.Indexes) == 1 && header.Indexes[0] == 0) {
+		return binary.AppendVarint(dst, 0)
+	}
+
+	dst = binary.AppendVarint(dst, int64(len(header.Indexes)))
+	for _, index := range header.Indexes {
+		dst = binary.AppendVarint(dst, int64(index))
+	}
+
+	return dst
+}
@@ -0,0 +1,126 @@
+package protomap
+
+import (
+	"bufio"
+	"errors"
+	"io"
+
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// DefaultMaxMessageSize is used by stream reader and writer when max size is not positive.
+const DefaultMaxMessageSize = 4 << 20
+
+// StreamReader decodes varint length-delimited messages from underlying reader.
+type StreamReader struct {
+	mapper  *Mapper
+	r       *bufio.Reader
+	desc    protoreflect.MessageDescriptor
+	maxSize int
+	inters  []DecodeInterceptor
+}
+
+// NewStreamReader creates reader that decodes successive messages of type messageName from r.
+// Messages larger than maxSize bytes are rejected; if maxSize <= 0, DefaultMaxMessageSize is used.
+func (m *Mapper) NewStreamReader(r io.Reader, messageName string, maxSize int, inters ...DecodeInterceptor) (*StreamReader, error) {
+	desc, err := m.findMessage(messageName)
+	if err != nil {
+		return nil, err
+	}
+
+	if maxSize <= 0 {
+		maxSize = DefaultMaxMessageSize
+	}
+
+	br, ok := r.(*bufio.Reader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+
+	return &StreamReader{
+		mapper:  m,
+		r:       br,
+		desc:    desc,
+		maxSize: maxSize,
+		inters:  inters,
+	}, nil
+}
+
+// Next reads and decodes next message from stream.
+// It returns io.EOF when stream ends cleanly on a message boundary,
+// and io.ErrUnexpectedEOF if stream ends in the middle of a message.
+// Oversized message is skipped with *protodelim.SizeTooLargeError, so reading may go on.
+func (s *StreamReader) Next() (any, error) {
+	message := dynamicpb.NewMessage(s.desc)
+	err := protodelim.UnmarshalOptions{MaxSize: int64(s.maxSize)}.UnmarshalFrom(s.r, message)
+	var sizeErr *protodelim.SizeTooLargeError
+	if errors.As(err, &sizeErr) {
+		// size prefix is already read, but payload is not
+		if _, err := io.CopyN(io.Discard, s.r, int64(sizeErr.Size)); err != nil {
+			if err == io.EOF {
+				return nil, io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mapper.MessageToAny(message, s.inters...)
+}
+
+// StreamWriter encodes messages to underlying writer, prefixing each one with its varint length.
+type StreamWriter struct {
+	mapper  *Mapper
+	w       io.Writer
+	desc    protoreflect.MessageDescriptor
+	maxSize int
+	inters  []EncodeInterceptor
+}
+
+// NewStreamWriter creates writer that encodes messages of type messageName to w.
+// Messages larger than maxSize bytes are rejected; if maxSize <= 0, DefaultMaxMessageSize is used.
+func (m *Mapper) NewStreamWriter(w io.Writer, messageName string, maxSize int, inters ...EncodeInterceptor) (*StreamWriter, error) {
+	desc, err := m.findMessage(messageName)
+	if err != nil {
+		return nil, err
+	}
+
+	if maxSize <= 0 {
+		maxSize = DefaultMaxMessageSize
+	}
+
+	return &StreamWriter{
+		mapper:  m,
+		w:       w,
+		desc:    desc,
+		maxSize: maxSize,
+		inters:  inters,
+	}, nil
+}
+
+// Write encodes data and writes it to stream with length prefix.
+func (s *StreamWriter) Write(data any) error {
+	message := dynamicpb.NewMessage(s.desc)
+	if err := s.mapper.AnyToMessage(data, message, s.inters...); err != nil {
+		return err
+	}
+
+	payload, err := s.mapper.marshal(message)
+	if err != nil {
+		return err
+	}
+
+	if len(payload) > s.maxSize {
+		return &protodelim.SizeTooLargeError{Size: uint64(len(payload)), MaxSize: uint64(s.maxSize)}
+	}
+
+	buf := protowire.AppendVarint(make([]byte, 0, protowire.SizeVarint(uint64(len(payload)))+len(payload)), uint64(len(payload)))
+	_, err = s.w.Write(append(buf, payload...))
+	return err
+}
@@ -0,0 +1,138 @@
+package protomap_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/gekatateam/protomap"
+	"google.golang.org/protobuf/encoding/protodelim"
+)
+
+func TestStream_WriteThenRead(t *testing.T) {
+	mapper, err := protomap.NewMapper(nil, testProto)
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	tjson, err := os.ReadFile(testJson)
+	if err != nil {
+		t.Fatalf("json data reading failed: %v", err)
+	}
+
+	buf := &bytes.Buffer{}
+	writer, err := mapper.NewStreamWriter(buf, testMessage, 0)
+	if err != nil {
+		t.Fatalf("writer creation failed: %v", err)
+	}
+
+	const count = 3
+	for i := 0; i < count; i++ {
+		input := make(map[string]any)
+		if err := json.Unmarshal(tjson, &input); err != nil {
+			t.Fatalf("json data unmarshaling failed: %v", err)
+		}
+
+		input, err = setInputKeysWithTypes(input)
+		if err != nil {
+			t.Fatalf("map input preparation failed: %v", err)
+		}
+
+		if err := writer.Write(input); err != nil {
+			t.Fatalf("message %v writing failed: %v", i, err)
+		}
+	}
+
+	expected := make(map[string]any)
+	if err := json.Unmarshal(tjson, &expected); err != nil {
+		t.Fatalf("json data unmarshaling failed: %v", err)
+	}
+
+	expected, err = setExpectedKeysWithTypes(expected)
+	if err != nil {
+		t.Fatalf("expected data preparation failed: %v", err)
+	}
+
+	reader, err := mapper.NewStreamReader(buf, testMessage, 0)
+	if err != nil {
+		t.Fatalf("reader creation failed: %v", err)
+	}
+
+	read := 0
+	for {
+		result, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("message %v reading failed: %v", read, err)
+		}
+
+		if !reflect.DeepEqual(expected, result) {
+			t.Fatalf("message %v: expected and result are not equal", read)
+		}
+		read++
+	}
+
+	if read != count {
+		t.Fatalf("expected %v messages, got %v", count, read)
+	}
+}
+
+func TestStream_MaxSize(t *testing.T) {
+	mapper, err := protomap.NewMapper(nil, testProto)
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	buf := &bytes.Buffer{}
+	writer, err := mapper.NewStreamWriter(buf, "protomap.test.Inner", 8)
+	if err != nil {
+		t.Fatalf("writer creation failed: %v", err)
+	}
+
+	var sizeErr *protodelim.SizeTooLargeError
+	err = writer.Write(map[string]any{"Foo": "longer than eight bytes", "List": []any{}})
+	if !errors.As(err, &sizeErr) {
+		t.Fatalf("expected size error on write, got %v", err)
+	}
+
+	unlimited, err := mapper.NewStreamWriter(buf, "protomap.test.Inner", 0)
+	if err != nil {
+		t.Fatalf("writer creation failed: %v", err)
+	}
+
+	if err := unlimited.Write(map[string]any{"Foo": "longer than eight bytes", "List": []any{}}); err != nil {
+		t.Fatalf("message writing failed: %v", err)
+	}
+
+	if err := unlimited.Write(map[string]any{"Foo": "ok", "List": []any{}}); err != nil {
+		t.Fatalf("message writing failed: %v", err)
+	}
+
+	reader, err := mapper.NewStreamReader(buf, "protomap.test.Inner", 8)
+	if err != nil {
+		t.Fatalf("reader creation failed: %v", err)
+	}
+
+	if _, err := reader.Next(); !errors.As(err, &sizeErr) {
+		t.Fatalf("expected size error on read, got %v", err)
+	}
+
+	result, err := reader.Next()
+	if err != nil {
+		t.Fatalf("expected reading after oversized message, got %v", err)
+	}
+
+	if result.(map[string]any)["Foo"] != "ok" {
+		t.Fatalf("expected message after oversized one, got %v", result)
+	}
+
+	if _, err := reader.Next(); err != io.EOF {
+		t.Fatalf("expected EOF, got %v", err)
+	}
+}
@@ -0,0 +1,237 @@
+package protomap
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Violation describes single mismatch between input data and message descriptor.
+// Path is a dot-separated field names with list indexes and map keys, like "Inner.List[1]" or "Map['key']".
+type Violation struct {
+	Path   string
+	Reason string
+}
+
+func (v Violation) String() string {
+	if v.Path == "" {
+		return v.Reason
+	}
+	return v.Path + ": " + v.Reason
+}
+
+// ValidationError holds all violations found by Validate.
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	reasons := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		reasons = append(reasons, v.String())
+	}
+	return fmt.Sprintf("%v violation(s): %v", len(e.Violations), strings.Join(reasons, "; "))
+}
+
+// Validate checks data against message descriptor the same way Encode converts it, but reports all violations
+// at once as *ValidationError, without building a message; data accepted by Encode is valid.
+// Values handled by registry interceptors are not checked, as it requires encoding.
+func (m *Mapper) Validate(data any, messageName string) error {
+	desc, err := m.findMessage(messageName)
+	if err != nil {
+		return err
+	}
+
+	v := validator{registry: m.registry, options: m.options, strict: m.strict}
+	v.validateMessage(desc, data, "", "")
+
+	if len(v.violations) > 0 {
+		return &ValidationError{Violations: v.violations}
+	}
+	return nil
+}
+
+// WithStrictValidation returns Mapper copy which Validate also reports input that Encode silently accepts:
+// unknown keys, several members of one oneof and values out of 32-bit field ranges.
+func (m *Mapper) WithStrictValidation() *Mapper {
+	mapper := *m
+	mapper.strict = true
+	return &mapper
+}
+
+type validator struct {
+	registry   *Registry
+	options    *optionsCache
+	strict     bool
+	violations []Violation
+}
+
+func (v *validator) add(at string, format string, args ...any) {
+	v.violations = append(v.violations, Violation{Path: at, Reason: fmt.Sprintf(format, args...)})
+}
+
+// validateMessage checks input of message; path is used for field selectors, at - for violations.
+func (v *validator) validateMessage(desc protoreflect.MessageDescriptor, input any, path, at string) {
+	if v.registry != nil {
+		if _, ok := v.registry.encoders[desc.FullName()]; ok {
+			return
+		}
+	}
+
+	// nil input leaves message empty
+	if input == nil {
+		return
+	}
+
+	data, ok := input.(map[string]any)
+	if !ok {
+		v.add(at, "expected map[string]any, got %T", input)
+		return
+	}
+
+	fields := desc.Fields()
+	omitEmpty := v.options.get(desc).omitEmpty
+	oneOfs := make(map[protoreflect.Name]protoreflect.Name)
+
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		fieldPath := joinPath(path, field.Name())
+		fieldAt := joinPath(at, field.Name())
+
+		opts := v.options.get(field)
+		if opts.skip {
+			continue
+		}
+
+		value, ok := data[string(field.Name())]
+		if !ok {
+			switch {
+			case opts.hasDefault && !field.IsList() && !field.IsMap():
+				value = opts.def
+			case field.Cardinality() == protoreflect.Optional || omitEmpty:
+				continue
+			default:
+				v.add(fieldAt, "field is not optional, but input data has no such key")
+				continue
+			}
+		}
+
+		if oneOf := field.ContainingOneof(); v.strict && oneOf != nil && !oneOf.IsSynthetic() && value != nil {
+			if other, ok := oneOfs[oneOf.Name()]; ok {
+				v.add(fieldAt, "%v is already set, but only one field of oneof %v may be set", other, oneOf.Name())
+			} else {
+				oneOfs[oneOf.Name()] = field.Name()
+			}
+		}
+
+		if field.IsList() {
+			slice, ok := value.([]any)
+			if !ok {
+				v.add(fieldAt, "field is a list, but input data field is %T", value)
+				continue
+			}
+
+			for j, elem := range slice {
+				v.validateValue(field, field.Kind(), elem, fieldPath, fmt.Sprintf("%v[%v]", fieldAt, j))
+			}
+			continue
+		}
+
+		if field.IsMap() {
+			gomap, ok := value.(map[string]any)
+			if !ok {
+				v.add(fieldAt, "field is a map, but input data field is %T", value)
+				continue
+			}
+
+			for _, k := range sortedKeys(gomap) {
+				elemAt := fmt.Sprintf("%v['%v']", fieldAt, k)
+				if _, err := (encoder{}).kindToProto(field, field.MapKey().Kind(), k, fieldPath); err != nil {
+					v.add(elemAt, "invalid key: %v", err)
+					continue
+				}
+				v.validateValue(field, field.MapValue().Kind(), gomap[k], fieldPath, elemAt)
+			}
+			continue
+		}
+
+		v.validateValue(field, field.Kind(), value, fieldPath, fieldAt)
+	}
+
+	if !v.strict {
+		return
+	}
+
+	for _, k := range sortedKeys(data) {
+		if fields.ByName(protoreflect.Name(k)) == nil {
+			v.add(joinPath(at, protoreflect.Name(k)), "no such field in message %v", desc.FullName())
+		}
+	}
+}
+
+func (v *validator) validateValue(field protoreflect.FieldDescriptor, kind protoreflect.Kind, value any, path, at string) {
+	if v.registry != nil {
+		for _, f := range v.registry.fields {
+			if f.enc != nil && f.selector(field, path) {
+				return
+			}
+		}
+	}
+
+	if opts := v.options.get(field); opts.hasFormat {
+		result, applied, err := encodeFormat(opts.format, value)
+		if err != nil {
+			v.add(at, "%v", err)
+			return
+		}
+
+		if applied {
+			value = result
+		}
+	}
+
+	if kind == protoreflect.MessageKind || kind == protoreflect.GroupKind {
+		desc := field.Message()
+		if field.IsMap() {
+			desc = field.MapValue().Message()
+		}
+		v.validateMessage(desc, value, path, at)
+		return
+	}
+
+	if _, err := (encoder{}).kindToProto(field, kind, value, path); err != nil {
+		v.add(at, "%v", err)
+		return
+	}
+
+	if !v.strict {
+		return
+	}
+
+	switch kind {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		if i, _ := AnyToInteger(value); i < math.MinInt32 || i > math.MaxInt32 {
+			v.add(at, "value %v is out of int32 range", i)
+		}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		if u, _ := AnyToUnsigned(value); u > math.MaxUint32 {
+			v.add(at, "value %v is out of uint32 range", u)
+		}
+	case protoreflect.FloatKind:
+		if f, _ := AnyToFloat(value); !math.IsInf(f, 0) && !math.IsNaN(f) && math.Abs(f) > math.MaxFloat32 {
+			v.add(at, "value %v is out of float range", f)
+		}
+	}
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,116 @@
+package protomap_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/gekatateam/protomap"
+)
+
+func TestValidate_ValidInput(t *testing.T) {
+	mapper, err := protomap.NewMapper(nil, testProto)
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	tjson, err := os.ReadFile(testJson)
+	if err != nil {
+		t.Fatalf("json data reading failed: %v", err)
+	}
+
+	input := make(map[string]any)
+	if err := json.Unmarshal(tjson, &input); err != nil {
+		t.Fatalf("json data unmarshaling failed: %v", err)
+	}
+
+	if err := mapper.Validate(input, testMessage); err != nil {
+		t.Fatalf("expected valid input, got %v", err)
+	}
+}
+
+func TestValidate_AllViolations(t *testing.T) {
+	mapper, err := protomap.NewMapper(nil, testProto)
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	input := map[string]any{
+		"String": "ok",
+		"Map":    map[string]any{"foo": []any{}},
+		"List":   "not a list",
+		"Int":    int64(1) << 40,
+		"Uint":   -1,
+		"Inner":  map[string]any{"List": []any{1, "two"}, "Bar": true},
+		"IntMap": map[string]any{"key": 1},
+		"Type":   "type",
+		"Number": 1.5,
+		"Enum":   "UNKNOWN",
+	}
+
+	expected := []string{
+		"Map['foo']",
+		"List",
+		"Uint",
+		"Inner.List[1]",
+		"IntMap['key']",
+		"Enum",
+	}
+
+	if paths := violationPaths(t, mapper.Validate(input, testMessage)); !reflect.DeepEqual(expected, paths) {
+		t.Fatalf("expected violations at %v, got %v", expected, paths)
+	}
+
+	if _, err := mapper.Encode(input, testMessage); err == nil {
+		t.Fatal("expected encoding error for invalid input")
+	}
+
+	expected = []string{
+		"Map['foo']",
+		"List",
+		"Int",
+		"Uint",
+		"Inner.List[1]",
+		"Inner.Bar",
+		"IntMap['key']",
+		"Number",
+		"Enum",
+	}
+
+	if paths := violationPaths(t, mapper.WithStrictValidation().Validate(input, testMessage)); !reflect.DeepEqual(expected, paths) {
+		t.Fatalf("expected strict violations at %v, got %v", expected, paths)
+	}
+}
+
+func TestValidate_EnumMap(t *testing.T) {
+	mapper, err := protomap.NewMapper(nil, "./testdata/maps.proto")
+	if err != nil {
+		t.Fatalf("mapper creation failed: %v", err)
+	}
+
+	if err := mapper.Validate(map[string]any{"States": map[string]any{"foo": "ACTIVE", "bar": 2}}, "protomap.test.WithEnumMap"); err != nil {
+		t.Fatalf("expected valid input, got %v", err)
+	}
+
+	err = mapper.Validate(map[string]any{"States": map[string]any{"foo": "NOPE"}}, "protomap.test.WithEnumMap")
+	if paths := violationPaths(t, err); !reflect.DeepEqual([]string{"States['foo']"}, paths) {
+		t.Fatalf("expected violation at States['foo'], got %v", paths)
+	}
+}
+
+func violationPaths(t *testing.T, err error) []string {
+	t.Helper()
+
+	var verr *protomap.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+
+	var paths []string
+	for _, v := range verr.Violations {
+		paths = append(paths, v.Path)
+	}
+	return paths
+}